@@ -3,10 +3,16 @@ package main
 import (
 	"currency/internal/app"
 	"log"
+	"os"
 )
 
 func main() {
-	err := app.Run()
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = app.Migrate(os.Args[2:])
+	} else {
+		err = app.Run()
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
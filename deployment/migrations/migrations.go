@@ -0,0 +1,116 @@
+// Package migrations embeds the SQL migrations into the binary, one
+// directory per database driver, and loads them for the migrators.
+//
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql. A
+// file may wrap its statements in BEGIN; ... END;, the migrators run every
+// file in a transaction of its own anyway.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed postgres/*.sql
+var Postgres embed.FS
+
+//go:embed sqlite/*.sql
+var SQLite embed.FS
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a schema change and its rollback.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and the time it was applied at, zero if it is
+// pending.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Load reads the migrations in dir of fsys ordered by version. Every
+// migration needs both an up and a down file.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	sub, err := fs.Sub(fsys, path.Clean(dir))
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("migrations dir %s", dir))
+	}
+	entries, err := fs.ReadDir(sub, ".")
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("read migrations: %s", err))
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("unexpected migration file %s", entry.Name()))
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("migration version %s", match[1]))
+		}
+		content, err := fs.ReadFile(sub, entry.Name())
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("read migration %s: %s", entry.Name(), err))
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("migrations %s and %s share version %d", m.Name, match[2], version))
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("migration %d_%s needs up and down files", m.Version, m.Name))
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// StripTransaction removes the BEGIN; ... END; around a migration, which
+// then runs in the transaction that records its version.
+func StripTransaction(sql string) string {
+	trimmed := strings.TrimSpace(sql)
+	upper := strings.ToUpper(trimmed)
+	if !strings.HasPrefix(upper, "BEGIN;") {
+		return sql
+	}
+	for _, end := range []string{"END;", "COMMIT;"} {
+		if strings.HasSuffix(upper, end) {
+			return strings.TrimSpace(trimmed[len("BEGIN;") : len(trimmed)-len(end)])
+		}
+	}
+	return sql
+}
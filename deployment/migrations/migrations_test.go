@@ -0,0 +1,73 @@
+package migrations_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"currency/deployment/migrations"
+	"currency/internal/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("embedded", func(t *testing.T) {
+		loaded, err := migrations.Load(migrations.Postgres, "postgres")
+		require.NoError(t, err)
+		require.NotEmpty(t, loaded)
+		assert.Equal(t, migrations.Migration{
+			Version: 1746255856,
+			Name:    "init",
+			Up:      loaded[0].Up,
+			Down:    loaded[0].Down,
+		}, loaded[0])
+		for i := 1; i < len(loaded); i++ {
+			assert.Less(t, loaded[i-1].Version, loaded[i].Version)
+		}
+	})
+
+	file := func(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+	for name, fsys := range map[string]fstest.MapFS{
+		"no down": {
+			"1_init.up.sql": file("CREATE TABLE coins ();"),
+		},
+		"shared version": {
+			"1_init.up.sql":   file("CREATE TABLE coins ();"),
+			"1_init.down.sql": file("DROP TABLE coins;"),
+			"1_add.up.sql":    file("ALTER TABLE coins ADD COLUMN id BIGINT;"),
+			"1_add.down.sql":  file("ALTER TABLE coins DROP COLUMN id;"),
+		},
+		"unexpected file": {
+			"README.md": file("migrations"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := migrations.Load(fsys, ".")
+			assert.ErrorIs(t, err, entities.ErrInvalidParams)
+		})
+	}
+}
+
+func TestStripTransaction(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{name: "begin end", sql: "BEGIN;\nDROP TABLE coins;\nEND;\n", want: "DROP TABLE coins;"},
+		{name: "begin commit", sql: "begin;\nDROP TABLE coins;\ncommit;", want: "DROP TABLE coins;"},
+		{name: "no transaction", sql: "DROP TABLE coins;", want: "DROP TABLE coins;"},
+		{
+			name: "do block",
+			sql:  "BEGIN;\nDO $$\nBEGIN\n    DELETE FROM coins;\nEND $$;\nEND;",
+			want: "DO $$\nBEGIN\n    DELETE FROM coins;\nEND $$;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, migrations.StripTransaction(tt.sql))
+		})
+	}
+}
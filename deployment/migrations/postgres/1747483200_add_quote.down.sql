@@ -0,0 +1,9 @@
+BEGIN;
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'coins' AND column_name = 'quote') THEN
+        DELETE FROM coins WHERE quote <> 'RUB';
+        ALTER TABLE coins DROP COLUMN quote;
+    END IF;
+END $$;
+END;
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE coins ADD COLUMN IF NOT EXISTS quote VARCHAR(10) NOT NULL DEFAULT 'RUB';
+ALTER TABLE coins ALTER COLUMN quote DROP DEFAULT;
+END;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE coins DROP COLUMN IF EXISTS provider;
+END;
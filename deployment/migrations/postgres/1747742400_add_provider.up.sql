@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE coins ADD COLUMN IF NOT EXISTS provider VARCHAR(100) NOT NULL DEFAULT '';
+END;
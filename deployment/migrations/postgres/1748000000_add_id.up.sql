@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE coins ADD COLUMN IF NOT EXISTS id BIGSERIAL PRIMARY KEY;
+END;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS alert_deliveries;
+DROP TABLE IF EXISTS alerts;
+END;
@@ -0,0 +1,24 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS alerts (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(50) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    condition VARCHAR(20) NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    cooldown_seconds BIGINT NOT NULL DEFAULT 0,
+    webhook_url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    last_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    last_fired_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    alert_id BIGINT NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS alert_deliveries_alert_id_idx ON alert_deliveries (alert_id, id);
+END;
@@ -0,0 +1,3 @@
+BEGIN;
+DROP TABLE IF EXISTS tracked_symbols;
+END;
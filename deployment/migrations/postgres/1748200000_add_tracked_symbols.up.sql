@@ -0,0 +1,8 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS tracked_symbols (
+    title VARCHAR(50) PRIMARY KEY,
+    paused BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+INSERT INTO tracked_symbols (title) SELECT DISTINCT title FROM coins ON CONFLICT DO NOTHING;
+END;
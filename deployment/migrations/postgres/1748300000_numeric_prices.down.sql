@@ -0,0 +1,5 @@
+BEGIN;
+ALTER TABLE alerts ALTER COLUMN last_price TYPE DOUBLE PRECISION USING last_price::double precision;
+ALTER TABLE alerts ALTER COLUMN threshold TYPE DOUBLE PRECISION USING threshold::double precision;
+ALTER TABLE coins ALTER COLUMN price TYPE REAL USING price::real;
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+-- через text: float4 -> numeric напрямую оставляет только 6 значащих цифр.
+ALTER TABLE coins ALTER COLUMN price TYPE NUMERIC USING price::text::numeric;
+ALTER TABLE alerts ALTER COLUMN threshold TYPE NUMERIC USING threshold::text::numeric;
+ALTER TABLE alerts ALTER COLUMN last_price TYPE NUMERIC USING last_price::text::numeric;
+END;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP INDEX IF EXISTS coins_title_created_at_idx;
+DROP TABLE IF EXISTS latest_prices;
+END;
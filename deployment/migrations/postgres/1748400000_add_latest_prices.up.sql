@@ -0,0 +1,17 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS latest_prices (
+    title VARCHAR(50) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    price NUMERIC NOT NULL,
+    provider VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    coin_id BIGINT NOT NULL,
+    PRIMARY KEY (title, quote)
+);
+INSERT INTO latest_prices (title, quote, price, provider, created_at, coin_id)
+SELECT DISTINCT ON (title, quote) title, quote, price, provider, created_at, id FROM coins
+ORDER BY title, quote, created_at DESC, id DESC
+ON CONFLICT DO NOTHING;
+-- миграции идут в транзакции, поэтому без CONCURRENTLY: запись в coins ждёт построения индекса.
+CREATE INDEX IF NOT EXISTS coins_title_created_at_idx ON coins (title, created_at);
+END;
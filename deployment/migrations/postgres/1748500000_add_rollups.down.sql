@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS prices_1d;
+DROP TABLE IF EXISTS prices_1h;
+END;
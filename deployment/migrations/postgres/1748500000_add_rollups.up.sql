@@ -0,0 +1,17 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS prices_1h (
+    title VARCHAR(50) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    bucket TIMESTAMP NOT NULL,
+    first_price NUMERIC NOT NULL,
+    last_price NUMERIC NOT NULL,
+    min_price NUMERIC NOT NULL,
+    max_price NUMERIC NOT NULL,
+    sum_price NUMERIC NOT NULL,
+    count BIGINT NOT NULL,
+    first_at TIMESTAMP NOT NULL,
+    last_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (title, quote, bucket)
+);
+CREATE TABLE IF NOT EXISTS prices_1d (LIKE prices_1h INCLUDING ALL);
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+DROP TABLE IF EXISTS alert_deliveries;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS tracked_symbols;
+DROP TABLE IF EXISTS latest_prices;
+DROP TABLE IF EXISTS coins;
+END;
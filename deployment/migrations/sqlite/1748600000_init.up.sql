@@ -0,0 +1,50 @@
+BEGIN;
+-- SQLite has neither NUMERIC nor timezone-aware timestamps of its own:
+-- prices are exact decimal text and times are UTC text of a fixed width, so
+-- they compare in order as strings.
+CREATE TABLE IF NOT EXISTS coins (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    price TEXT NOT NULL,
+    provider TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS coins_title_created_at_idx ON coins (title, created_at);
+CREATE TABLE IF NOT EXISTS latest_prices (
+    title TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    price TEXT NOT NULL,
+    provider TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    coin_id INTEGER NOT NULL,
+    PRIMARY KEY (title, quote)
+);
+CREATE TABLE IF NOT EXISTS tracked_symbols (
+    title TEXT PRIMARY KEY,
+    paused INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    condition TEXT NOT NULL,
+    threshold TEXT NOT NULL,
+    cooldown_seconds INTEGER NOT NULL DEFAULT 0,
+    webhook_url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    last_price TEXT NOT NULL DEFAULT '0',
+    last_fired_at TEXT,
+    created_at TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    alert_id INTEGER NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS alert_deliveries_alert_id_idx ON alert_deliveries (alert_id, id);
+END;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE coins DROP COLUMN price_sort;
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+-- price_sort is price as REAL, used only to order prices, so MIN and MAX
+-- don't read every price into the service. The exact price stays in price
+-- and is what MIN and MAX return.
+ALTER TABLE coins ADD COLUMN price_sort REAL NOT NULL DEFAULT 0;
+UPDATE coins SET price_sort = CAST(price AS REAL);
+END;
@@ -0,0 +1,193 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// quoteAssets maps fiat quotes to the stablecoin Binance lists them against.
+var quoteAssets = map[string]string{"USD": "USDT"}
+
+// invalidSymbol is the Binance error code of an unlisted symbol.
+const invalidSymbol = -1121
+
+var errInvalidSymbol = errors.New("invalid symbol")
+
+// Client reads the Binance public ticker (GET /api/v3/ticker/price).
+type Client struct {
+	client *http.Client
+	url    *url.URL
+	quotes []string
+
+	mu sync.Mutex
+	// unlisted are the symbols Binance rejected, left out of later requests.
+	unlisted map[string]bool
+}
+
+func NewClient(rawURL string, quotes []string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("url: %s", rawURL))
+	}
+	if len(quotes) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "quotes are empty")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{client: httpClient, url: u, quotes: quotes, unlisted: make(map[string]bool)}, nil
+}
+
+type ticker struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+type apiError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// GetCoins requests every title/quote pair in one call. Binance rejects the
+// whole request when any symbol is unlisted, e.g. BTCRUB, so then every pair
+// is requested on its own and the unlisted ones are skipped from then on.
+func (c *Client) GetCoins(ctx context.Context, titles []string, quotes []string) ([]entities.Coin, error) {
+	if len(quotes) == 0 {
+		quotes = c.quotes
+	}
+
+	type pair struct{ title, quote string }
+	pairs := make(map[string]pair)
+	symbols := make([]string, 0, len(titles)*len(quotes))
+	c.mu.Lock()
+	for _, t := range titles {
+		for _, q := range quotes {
+			asset := q
+			if a, ok := quoteAssets[q]; ok {
+				asset = a
+			}
+			symbol := t + asset
+			if c.unlisted[symbol] {
+				continue
+			}
+			pairs[symbol] = pair{title: t, quote: q}
+			symbols = append(symbols, symbol)
+		}
+	}
+	c.mu.Unlock()
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+
+	quoted := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		quoted = append(quoted, strconv.Quote(symbol))
+	}
+	var tickers []ticker
+	err := c.get(ctx, "symbols", "["+strings.Join(quoted, ",")+"]", &tickers)
+	if errors.Is(err, errInvalidSymbol) {
+		tickers, err = c.getEach(ctx, symbols)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var coins []entities.Coin
+	for _, t := range tickers {
+		p, ok := pairs[t.Symbol]
+		if !ok {
+			continue
+		}
+		price, err := decimal.NewFromString(t.Price)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("price of %s: %s", t.Symbol, t.Price))
+		}
+		coin, err := entities.NewCoin(p.title, p.quote, price, now)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, *coin)
+	}
+
+	sort.Slice(coins, func(i, j int) bool {
+		if coins[i].Title != coins[j].Title {
+			return coins[i].Title < coins[j].Title
+		}
+		return coins[i].Quote < coins[j].Quote
+	})
+
+	return coins, nil
+}
+
+// getEach requests symbols one by one and remembers the unlisted ones.
+func (c *Client) getEach(ctx context.Context, symbols []string) ([]ticker, error) {
+	var tickers []ticker
+	for _, symbol := range symbols {
+		var t ticker
+		err := c.get(ctx, "symbol", symbol, &t)
+		if errors.Is(err, errInvalidSymbol) {
+			c.mu.Lock()
+			c.unlisted[symbol] = true
+			c.mu.Unlock()
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tickers = append(tickers, t)
+	}
+	return tickers, nil
+}
+
+// get requests the ticker with the query parameter key set to value and
+// decodes it into v. An unlisted symbol is reported as errInvalidSymbol.
+func (c *Client) get(ctx context.Context, key, value string, v any) error {
+	u := *c.url
+	qs := u.Query()
+	qs.Set(key, value)
+	u.RawQuery = qs.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "Couldn't form a request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Couldn't get %s", value))
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "Couldn't count the response")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+		if resp.StatusCode == http.StatusBadRequest && json.Unmarshal(bodyBytes, &apiErr) == nil && apiErr.Code == invalidSymbol {
+			return errInvalidSymbol
+		}
+		return fmt.Errorf("Status Error: %s\n", resp.Status)
+	}
+
+	if err := json.Unmarshal(bodyBytes, v); err != nil {
+		return errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("symbols: %s", value))
+	}
+	return nil
+}
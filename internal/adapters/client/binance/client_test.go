@@ -0,0 +1,112 @@
+package binance_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"currency/internal/adapters/client/binance"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetCoins(t *testing.T) {
+	t.Run("successful response", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, `["BTCUSDT","BTCEUR","ETHUSDT","ETHEUR"]`, r.URL.Query().Get("symbols"))
+
+			rw.Header().Set("Content-Type", "application/json")
+			rw.Write([]byte(`[
+				{"symbol": "BTCUSDT", "price": "103512.40000000"},
+				{"symbol": "BTCEUR", "price": "91240.70000000"},
+				{"symbol": "ETHUSDT", "price": "2540.10000000"},
+				{"symbol": "ETHEUR", "price": "2240.55000000"}
+			]`))
+		}))
+		defer testServer.Close()
+
+		client, err := binance.NewClient(testServer.URL, []string{"USD", "EUR"}, nil)
+		require.NoError(t, err)
+
+		coins, err := client.GetCoins(context.Background(), []string{"BTC", "ETH"}, nil)
+		require.NoError(t, err)
+
+		require.Len(t, coins, 4)
+		assert.Equal(t, "BTC", coins[0].Title)
+		assert.Equal(t, "EUR", coins[0].Quote)
+		assert.Equal(t, "91240.7", coins[0].Price.String())
+		assert.Equal(t, "BTC", coins[1].Title)
+		assert.Equal(t, "USD", coins[1].Quote)
+		assert.Equal(t, "103512.4", coins[1].Price.String())
+	})
+
+	t.Run("unlisted quote is skipped", func(t *testing.T) {
+		var requests []string
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			requests = append(requests, query.Encode())
+
+			rw.Header().Set("Content-Type", "application/json")
+			switch {
+			case query.Get("symbols") == `["BTCUSDT"]`:
+				rw.Write([]byte(`[{"symbol": "BTCUSDT", "price": "103512.40000000"}]`))
+			case query.Get("symbol") == "BTCUSDT":
+				rw.Write([]byte(`{"symbol": "BTCUSDT", "price": "103512.40000000"}`))
+			default:
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write([]byte(`{"code": -1121, "msg": "Invalid symbol."}`))
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := binance.NewClient(testServer.URL, []string{"USD", "RUB"}, nil)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			coins, err := client.GetCoins(context.Background(), []string{"BTC"}, nil)
+			require.NoError(t, err)
+			require.Len(t, coins, 1)
+			assert.Equal(t, "USD", coins[0].Quote)
+			assert.Equal(t, "103512.4", coins[0].Price.String())
+		}
+
+		assert.Equal(t, []string{
+			"symbols=" + url.QueryEscape(`["BTCUSDT","BTCRUB"]`),
+			"symbol=BTCUSDT",
+			"symbol=BTCRUB",
+			"symbols=" + url.QueryEscape(`["BTCUSDT"]`),
+		}, requests)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code": -1100, "msg": "Illegal characters found in parameter 'symbols'."}`))
+		}))
+		defer testServer.Close()
+
+		client, err := binance.NewClient(testServer.URL, []string{"USD"}, nil)
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Status Error: 400 Bad Request")
+	})
+
+	t.Run("invalid price", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte(`[{"symbol": "BTCUSDT", "price": "n/a"}]`))
+		}))
+		defer testServer.Close()
+
+		client, err := binance.NewClient(testServer.URL, []string{"USD"}, nil)
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid Params")
+	})
+}
@@ -0,0 +1,108 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Client reads Coinbase spot prices (GET /v2/prices/{BASE}-{QUOTE}/spot).
+type Client struct {
+	client *http.Client
+	url    string
+	quotes []string
+}
+
+func NewClient(rawURL string, quotes []string, httpClient *http.Client) (*Client, error) {
+	if _, err := url.Parse(rawURL); err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("url: %s", rawURL))
+	}
+	if len(quotes) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "quotes are empty")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{client: httpClient, url: strings.TrimSuffix(rawURL, "/"), quotes: quotes}, nil
+}
+
+type spotResponse struct {
+	Data struct {
+		Base     string `json:"base"`
+		Currency string `json:"currency"`
+		Amount   string `json:"amount"`
+	} `json:"data"`
+}
+
+// GetCoins issues one request per title/quote pair. Pairs Coinbase doesn't
+// list (404) are skipped, any other failure aborts the call.
+func (c *Client) GetCoins(ctx context.Context, titles []string, quotes []string) ([]entities.Coin, error) {
+	if len(quotes) == 0 {
+		quotes = c.quotes
+	}
+
+	var coins []entities.Coin
+	for _, t := range titles {
+		for _, q := range quotes {
+			coin, err := c.getSpot(ctx, t, q)
+			if err != nil {
+				return nil, err
+			}
+			if coin != nil {
+				coins = append(coins, *coin)
+			}
+		}
+	}
+
+	return coins, nil
+}
+
+func (c *Client) getSpot(ctx context.Context, title, quote string) (*entities.Coin, error) {
+	pair := fmt.Sprintf("%s-%s", title, quote)
+	u := fmt.Sprintf("%s/%s/spot", c.url, url.PathEscape(pair))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Couldn't form a request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Couldn't get %s", pair))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Status Error: %s\n", resp.Status)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Couldn't count the response")
+	}
+
+	var spot spotResponse
+	if err := json.Unmarshal(bodyBytes, &spot); err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("pair: %s", pair))
+	}
+
+	price, err := decimal.NewFromString(spot.Data.Amount)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("price of %s: %s", pair, spot.Data.Amount))
+	}
+
+	return entities.NewCoin(title, quote, price, time.Now())
+}
@@ -0,0 +1,54 @@
+package coinbase_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"currency/internal/adapters/client/coinbase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetCoins(t *testing.T) {
+	t.Run("successful response", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v2/prices/BTC-USD/spot":
+				rw.Write([]byte(`{"data": {"base": "BTC", "currency": "USD", "amount": "103512.4"}}`))
+			case "/v2/prices/BTC-RUB/spot":
+				rw.WriteHeader(http.StatusNotFound)
+			default:
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := coinbase.NewClient(testServer.URL+"/v2/prices/", []string{"USD"}, nil)
+		require.NoError(t, err)
+
+		coins, err := client.GetCoins(context.Background(), []string{"BTC"}, []string{"USD", "RUB"})
+		require.NoError(t, err)
+
+		require.Len(t, coins, 1)
+		assert.Equal(t, "BTC", coins[0].Title)
+		assert.Equal(t, "USD", coins[0].Quote)
+		assert.Equal(t, "103512.4", coins[0].Price.String())
+	})
+
+	t.Run("invalid status code", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer testServer.Close()
+
+		client, err := coinbase.NewClient(testServer.URL, []string{"USD"}, nil)
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Status Error: 500 Internal Server Error")
+	})
+}
@@ -6,38 +6,74 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"currency/internal/entities"
+	"currency/internal/tracing"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Client struct {
-	client http.Client
-	url    string
+	client *http.Client
+	url    *url.URL
+	quotes []string
 }
 
-func NewClient(url string) (*Client, error) {
-	cl := http.Client{}
-	return &Client{client: cl, url: url}, nil
+// NewClient creates a client for the pricemulti endpoint. quotes are the
+// default tsyms requested when GetCoins is called without explicit quotes.
+// A nil httpClient means a plain http.Client.
+func NewClient(rawURL string, quotes []string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("url: %s", rawURL))
+	}
+	if len(quotes) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "quotes are empty")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{client: httpClient, url: u, quotes: quotes}, nil
 }
 
-func (c *Client) GetCoins(ctx context.Context, titles []string) ([]entities.Coin, error) {
+var tracer = otel.Tracer("currency/internal/adapters/client/coindesk")
+
+func (c *Client) GetCoins(ctx context.Context, titles []string, quotes []string) (_ []entities.Coin, err error) {
+	if len(quotes) == 0 {
+		quotes = c.quotes
+	}
+	ctx, span := tracer.Start(ctx, "coindesk.GetCoins", trace.WithSpanKind(trace.SpanKindClient), tracing.Symbols(titles, quotes))
+	defer tracing.End(span, &err)
 	fsymsParams := strings.Join(titles, ",")
-	url := fmt.Sprintf("%s=%s", c.url, fsymsParams)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	u := *c.url
+	q := u.Query()
+	q.Set("fsyms", fsymsParams)
+	q.Set("tsyms", strings.Join(quotes, ","))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Couldn't form a request")
 	}
 
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("Couldn't get %s", fsymsParams))
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Status Error: %s\n", resp.Status)
@@ -48,21 +84,31 @@ func (c *Client) GetCoins(ctx context.Context, titles []string) ([]entities.Coin
 		return nil, errors.Wrap(err, "Couldn't count the response")
 	}
 
-	var priceData map[string]map[string]float64
+	var priceData map[string]map[string]decimal.Decimal
 
 	err = json.Unmarshal(bodyBytes, &priceData)
 	if err != nil {
 		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("titles: %s", fsymsParams))
 	}
 
+	now := time.Now()
 	var coins []entities.Coin
 	for coin, prices := range priceData {
-		c, err := entities.NewCoin(coin, prices["RUB"], time.Now())
-		if err != nil {
-			return nil, err
+		for quote, price := range prices {
+			c, err := entities.NewCoin(coin, quote, price, now)
+			if err != nil {
+				return nil, err
+			}
+			coins = append(coins, *c)
 		}
-		coins = append(coins, *c)
 	}
 
+	sort.Slice(coins, func(i, j int) bool {
+		if coins[i].Title != coins[j].Title {
+			return coins[i].Title < coins[j].Title
+		}
+		return coins[i].Quote < coins[j].Quote
+	})
+
 	return coins, nil
 }
@@ -2,7 +2,7 @@ package coindesk_test
 
 import (
 	"context"
-	"fmt"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -13,12 +13,17 @@ import (
 	"currency/internal/entities"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestClient_GetCoins(t *testing.T) {
 	t.Run("successful response", func(t *testing.T) {
 		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/?fsyms=BTC,ETH", r.URL.String())
+			assert.Equal(t, "BTC,ETH", r.URL.Query().Get("fsyms"))
+			assert.Equal(t, "RUB", r.URL.Query().Get("tsyms"))
 			assert.Equal(t, http.MethodGet, r.Method)
 
 			response := `{
@@ -32,13 +37,13 @@ func TestClient_GetCoins(t *testing.T) {
 		}))
 		defer testServer.Close()
 
-		client, err := coindesk.NewClient(testServer.URL + "?fsyms")
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, nil)
 		if err != nil {
 			t.Errorf("Error creating client: %v", err)
 			return
 		}
 
-		coins, err := client.GetCoins(context.Background(), []string{"BTC", "ETH"})
+		coins, err := client.GetCoins(context.Background(), []string{"BTC", "ETH"}, nil)
 		if err != nil {
 			t.Errorf("Error getting coins: %v", err)
 			return
@@ -46,15 +51,50 @@ func TestClient_GetCoins(t *testing.T) {
 
 		// Проверяем результаты
 		expected := []entities.Coin{
-			{Title: "BTC", Price: 8398290.1, CreateTime: time.Now()},
-			{Title: "ETH", Price: 196888.49, CreateTime: time.Now()},
+			{Title: "BTC", Quote: "RUB", Price: decimal.RequireFromString("8398290.1"), CreateTime: time.Now()},
+			{Title: "ETH", Quote: "RUB", Price: decimal.RequireFromString("196888.49"), CreateTime: time.Now()},
 		}
 
 		assert.Len(t, coins, 2)
 		assert.Equal(t, expected[0].Title, coins[0].Title)
-		assert.Equal(t, expected[0].Price, coins[0].Price)
+		assert.Equal(t, expected[0].Price.String(), coins[0].Price.String())
 		assert.Equal(t, expected[1].Title, coins[1].Title)
-		assert.Equal(t, expected[1].Price, coins[1].Price)
+		assert.Equal(t, expected[1].Price.String(), coins[1].Price.String())
+	})
+
+	t.Run("several quotes", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "BTC", r.URL.Query().Get("fsyms"))
+			assert.Equal(t, "USD,EUR", r.URL.Query().Get("tsyms"))
+
+			response := `{"BTC": {"USD": 103512.4, "EUR": 91240.7}}`
+
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(response))
+		}))
+		defer testServer.Close()
+
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, nil)
+		if err != nil {
+			t.Errorf("Error creating client: %v", err)
+			return
+		}
+
+		coins, err := client.GetCoins(context.Background(), []string{"BTC"}, []string{"USD", "EUR"})
+		require.NoError(t, err)
+
+		require.Len(t, coins, 2)
+		assert.Equal(t, "EUR", coins[0].Quote)
+		assert.Equal(t, "91240.7", coins[0].Price.String())
+		assert.Equal(t, "USD", coins[1].Quote)
+		assert.Equal(t, "103512.4", coins[1].Price.String())
+	})
+
+	t.Run("empty quotes", func(t *testing.T) {
+		_, err := coindesk.NewClient("http://localhost", nil, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, entities.ErrInvalidParams)
 	})
 
 	t.Run("empty response", func(t *testing.T) {
@@ -64,12 +104,12 @@ func TestClient_GetCoins(t *testing.T) {
 		}))
 		defer testServer.Close()
 
-		client, err := coindesk.NewClient(testServer.URL + "?fsyms")
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, nil)
 		if err != nil {
 			t.Errorf("Error creating client: %v", err)
 		}
 
-		coins, err := client.GetCoins(context.Background(), []string{"BTC", "ETH"})
+		coins, err := client.GetCoins(context.Background(), []string{"BTC", "ETH"}, nil)
 		if err != nil {
 			t.Errorf("Error getting coins: %v", err)
 			return
@@ -83,12 +123,12 @@ func TestClient_GetCoins(t *testing.T) {
 		}))
 		defer testServer.Close()
 
-		client, err := coindesk.NewClient(testServer.URL + "?fsyms")
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, nil)
 		if err != nil {
 			t.Errorf("Error creating client: %v", err)
 		}
 
-		_, err = client.GetCoins(context.Background(), []string{"BTC", "ETH"})
+		_, err = client.GetCoins(context.Background(), []string{"BTC", "ETH"}, nil)
 		require.Error(t, err)
 
 		assert.Contains(t, err.Error(), "Status Error: 500 Internal Server Error")
@@ -101,25 +141,25 @@ func TestClient_GetCoins(t *testing.T) {
 		}))
 		defer testServer.Close()
 
-		client, err := coindesk.NewClient(testServer.URL + "?fsyms")
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, nil)
 		if err != nil {
 			t.Errorf("Error creating client: %v", err)
 			return
 		}
 
-		_, err = client.GetCoins(context.Background(), []string{"BTCCC", "ETGFH"})
+		_, err = client.GetCoins(context.Background(), []string{"BTCCC", "ETGFH"}, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "Invalid Params")
 	})
 
 	t.Run("request error", func(t *testing.T) {
-		client, err := coindesk.NewClient("http://invalid-url")
+		client, err := coindesk.NewClient("http://invalid-url", []string{"RUB"}, nil)
 		if err != nil {
 			t.Errorf("Error creating client: %v", err)
 			return
 		}
 
-		_, err = client.GetCoins(context.Background(), []string{"BTC"})
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "Couldn't get BTC")
 	})
@@ -132,7 +172,7 @@ func TestClient_GetCoins(t *testing.T) {
 		}))
 		defer testServer.Close()
 
-		client, err := coindesk.NewClient(testServer.URL + "?fsyms")
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, nil)
 		if err != nil {
 			t.Errorf("Error creating client: %v", err)
 			return
@@ -141,9 +181,30 @@ func TestClient_GetCoins(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
 		defer cancel()
 
-		_, err = client.GetCoins(ctx, []string{"BTC"})
+		_, err = client.GetCoins(ctx, []string{"BTC"}, nil)
 		require.Error(t, err)
-		fmt.Println(err.Error())
 		assert.Contains(t, err.Error(), "context deadline exceeded")
 	})
+
+	t.Run("propagates trace context", func(t *testing.T) {
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		otel.SetTracerProvider(sdktrace.NewTracerProvider())
+		t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+		ctx, span := otel.Tracer("test").Start(context.Background(), "test")
+		defer span.End()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.Header.Get("traceparent"), span.SpanContext().TraceID().String())
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"BTC": {"RUB": 1}}`))
+		}))
+		defer testServer.Close()
+
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, nil)
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(ctx, []string{"BTC"}, nil)
+		require.NoError(t, err)
+	})
 }
@@ -0,0 +1,245 @@
+package composite
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"currency/internal/adapters/client/resilience"
+	"currency/internal/entities"
+	"currency/internal/usecases"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type Strategy string
+
+const (
+	// Fallback asks providers in priority order and only asks the next one
+	// for the pairs still missing.
+	Fallback Strategy = "fallback"
+	// Median asks every provider at once and returns the median price of
+	// each pair.
+	Median Strategy = "median"
+)
+
+// Provider is a named usecases.Client. Providers with an open Breaker are
+// skipped.
+type Provider struct {
+	Name    string
+	Client  usecases.Client
+	Breaker *resilience.Breaker
+}
+
+// Client spreads GetCoins over several providers and records in
+// entities.Coin.Provider which of them supplied each price.
+type Client struct {
+	providers []Provider
+	strategy  Strategy
+	logger    *slog.Logger
+}
+
+// NewClient combines providers with strategy. Failures of single providers
+// are logged to logger, GetCoins fails only when all of them do.
+func NewClient(strategy Strategy, logger *slog.Logger, providers ...Provider) (*Client, error) {
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	if len(providers) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "providers are empty")
+	}
+	for _, p := range providers {
+		if p.Client == nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("provider %s is nil", p.Name))
+		}
+	}
+	switch strategy {
+	case Fallback, Median:
+	default:
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("strategy: %s", strategy))
+	}
+	return &Client{providers: providers, strategy: strategy, logger: logger}, nil
+}
+
+func (c *Client) GetCoins(ctx context.Context, titles []string, quotes []string) ([]entities.Coin, error) {
+	var coins []entities.Coin
+	var err error
+	if c.strategy == Median {
+		coins, err = c.median(ctx, titles, quotes)
+	} else {
+		coins, err = c.fallback(ctx, titles, quotes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(coins, func(i, j int) bool {
+		if coins[i].Title != coins[j].Title {
+			return coins[i].Title < coins[j].Title
+		}
+		return coins[i].Quote < coins[j].Quote
+	})
+
+	return coins, nil
+}
+
+// States reports the circuit breaker state of every provider that has one.
+func (c *Client) States() map[string]resilience.State {
+	states := make(map[string]resilience.State, len(c.providers))
+	for _, p := range c.providers {
+		if p.Breaker != nil {
+			states[p.Name] = p.Breaker.State()
+		}
+	}
+	return states
+}
+
+func (p Provider) open() bool {
+	return p.Breaker != nil && p.Breaker.State() == resilience.Open
+}
+
+type pair struct{ title, quote string }
+
+func (c *Client) fallback(ctx context.Context, titles, quotes []string) ([]entities.Coin, error) {
+	var coins []entities.Coin
+	var errs []string
+	got := make(map[pair]bool)
+	gotTitle := make(map[string]bool)
+
+	for _, p := range c.providers {
+		if p.open() {
+			errs = append(errs, fmt.Sprintf("%s: %s", p.Name, resilience.ErrCircuitOpen))
+			continue
+		}
+
+		// A title is asked again while any of its quotes is missing. Without
+		// explicit quotes the providers pick their defaults, so a title is
+		// asked again only if no provider has returned it.
+		missing := titles
+		if len(got) > 0 {
+			missing = nil
+			for _, t := range titles {
+				if len(quotes) == 0 && !gotTitle[t] {
+					missing = append(missing, t)
+				}
+				for _, q := range quotes {
+					if !got[pair{t, q}] {
+						missing = append(missing, t)
+						break
+					}
+				}
+			}
+			if len(missing) == 0 {
+				break
+			}
+		}
+
+		cs, err := p.Client.GetCoins(ctx, missing, quotes)
+		if err != nil {
+			c.logger.WarnContext(ctx, "provider failed", slog.String("provider", p.Name), slog.Any("error", err))
+			errs = append(errs, fmt.Sprintf("%s: %s", p.Name, err))
+			continue
+		}
+
+		for _, coin := range cs {
+			key := pair{coin.Title, coin.Quote}
+			if got[key] {
+				continue
+			}
+			got[key], gotTitle[coin.Title] = true, true
+			coin.Provider = p.Name
+			coins = append(coins, coin)
+		}
+	}
+
+	if len(coins) == 0 && len(errs) > 0 {
+		return nil, errors.Errorf("all providers failed: %s", strings.Join(errs, "; "))
+	}
+
+	return coins, nil
+}
+
+func (c *Client) median(ctx context.Context, titles, quotes []string) ([]entities.Coin, error) {
+	type result struct {
+		name  string
+		coins []entities.Coin
+		err   error
+	}
+
+	results := make([]result, len(c.providers))
+	var wg sync.WaitGroup
+	for i, p := range c.providers {
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+			if p.open() {
+				results[i] = result{name: p.Name, err: resilience.ErrCircuitOpen}
+				return
+			}
+			cs, err := p.Client.GetCoins(ctx, titles, quotes)
+			results[i] = result{name: p.Name, coins: cs, err: err}
+		}(i, p)
+	}
+	wg.Wait()
+
+	var errs []string
+	var order []pair
+	byPair := make(map[pair][]entities.Coin)
+	providers := make(map[pair][]string)
+	for _, r := range results {
+		if r.err != nil {
+			c.logger.WarnContext(ctx, "provider failed", slog.String("provider", r.name), slog.Any("error", r.err))
+			errs = append(errs, fmt.Sprintf("%s: %s", r.name, r.err))
+			continue
+		}
+		for _, coin := range r.coins {
+			key := pair{coin.Title, coin.Quote}
+			if _, ok := byPair[key]; !ok {
+				order = append(order, key)
+			}
+			byPair[key] = append(byPair[key], coin)
+			providers[key] = append(providers[key], r.name)
+		}
+	}
+
+	if len(order) == 0 && len(errs) > 0 {
+		return nil, errors.Errorf("all providers failed: %s", strings.Join(errs, "; "))
+	}
+
+	coins := make([]entities.Coin, 0, len(order))
+	for _, key := range order {
+		cs := byPair[key]
+		prices := make([]decimal.Decimal, len(cs))
+		for i, coin := range cs {
+			prices[i] = coin.Price
+		}
+
+		coin := cs[0]
+		coin.Price = median(prices)
+		for _, other := range cs[1:] {
+			if other.CreateTime.After(coin.CreateTime) {
+				coin.CreateTime = other.CreateTime
+			}
+		}
+		names := providers[key]
+		sort.Strings(names)
+		coin.Provider = strings.Join(names, ",")
+		coins = append(coins, coin)
+	}
+
+	return coins, nil
+}
+
+func median(prices []decimal.Decimal) decimal.Decimal {
+	sort.Slice(prices, func(i, j int) bool { return prices[i].LessThan(prices[j]) })
+	n := len(prices)
+	if n%2 == 1 {
+		return prices[n/2]
+	}
+	// умножение на 0.5 точное, в отличие от деления на 2 с DivisionPrecision.
+	return prices[n/2-1].Add(prices[n/2]).Mul(decimal.New(5, -1))
+}
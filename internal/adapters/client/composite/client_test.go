@@ -0,0 +1,181 @@
+package composite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"currency/internal/adapters/client/composite"
+	"currency/internal/adapters/client/resilience"
+	"currency/internal/entities"
+	"currency/internal/logging"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func coin(title, quote, price string) entities.Coin {
+	return entities.Coin{Title: title, Quote: quote, Price: decimal.RequireFromString(price), CreateTime: time.Unix(0, 0)}
+}
+
+func TestNewClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := composite.NewClient(composite.Fallback, logging.Nop())
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+
+	_, err = composite.NewClient("random", logging.Nop(), composite.Provider{Name: "a", Client: mock.NewMockClient(ctrl)})
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+
+	_, err = composite.NewClient(composite.Fallback, nil, composite.Provider{Name: "a", Client: mock.NewMockClient(ctrl)})
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+}
+
+func TestClient_Fallback(t *testing.T) {
+	titles := []string{"BTC", "ETH"}
+	quotes := []string{"USD"}
+
+	t.Run("first provider answers everything", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first, second := mock.NewMockClient(ctrl), mock.NewMockClient(ctrl)
+		first.EXPECT().GetCoins(gomock.Any(), titles, quotes).
+			Return([]entities.Coin{coin("ETH", "USD", "2"), coin("BTC", "USD", "1")}, nil)
+
+		c, err := composite.NewClient(composite.Fallback, logging.Nop(),
+			composite.Provider{Name: "first", Client: first},
+			composite.Provider{Name: "second", Client: second})
+		require.NoError(t, err)
+
+		coins, err := c.GetCoins(context.Background(), titles, quotes)
+		require.NoError(t, err)
+		require.Len(t, coins, 2)
+		assert.Equal(t, "BTC", coins[0].Title)
+		assert.Equal(t, "first", coins[0].Provider)
+		assert.Equal(t, "first", coins[1].Provider)
+	})
+
+	t.Run("next provider fills the gaps", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first, second, third := mock.NewMockClient(ctrl), mock.NewMockClient(ctrl), mock.NewMockClient(ctrl)
+		gomock.InOrder(
+			first.EXPECT().GetCoins(gomock.Any(), titles, quotes).Return(nil, errors.New("outage")),
+			second.EXPECT().GetCoins(gomock.Any(), titles, quotes).Return([]entities.Coin{coin("BTC", "USD", "1")}, nil),
+			third.EXPECT().GetCoins(gomock.Any(), []string{"ETH"}, quotes).Return([]entities.Coin{coin("ETH", "USD", "2")}, nil),
+		)
+
+		c, err := composite.NewClient(composite.Fallback, logging.Nop(),
+			composite.Provider{Name: "first", Client: first},
+			composite.Provider{Name: "second", Client: second},
+			composite.Provider{Name: "third", Client: third})
+		require.NoError(t, err)
+
+		coins, err := c.GetCoins(context.Background(), titles, quotes)
+		require.NoError(t, err)
+		require.Len(t, coins, 2)
+		assert.Equal(t, "second", coins[0].Provider)
+		assert.Equal(t, "third", coins[1].Provider)
+	})
+
+	t.Run("default quotes fill the missing titles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first, second := mock.NewMockClient(ctrl), mock.NewMockClient(ctrl)
+		gomock.InOrder(
+			first.EXPECT().GetCoins(gomock.Any(), titles, nil).Return([]entities.Coin{coin("BTC", "USD", "1"), coin("BTC", "EUR", "1")}, nil),
+			second.EXPECT().GetCoins(gomock.Any(), []string{"ETH"}, nil).Return([]entities.Coin{coin("ETH", "USD", "2")}, nil),
+		)
+
+		c, err := composite.NewClient(composite.Fallback, logging.Nop(),
+			composite.Provider{Name: "first", Client: first},
+			composite.Provider{Name: "second", Client: second})
+		require.NoError(t, err)
+
+		coins, err := c.GetCoins(context.Background(), titles, nil)
+		require.NoError(t, err)
+		require.Len(t, coins, 3)
+		assert.Equal(t, "ETH", coins[2].Title)
+		assert.Equal(t, "second", coins[2].Provider)
+	})
+
+	t.Run("all providers fail", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first, second := mock.NewMockClient(ctrl), mock.NewMockClient(ctrl)
+		first.EXPECT().GetCoins(gomock.Any(), titles, quotes).Return(nil, errors.New("outage"))
+		second.EXPECT().GetCoins(gomock.Any(), titles, quotes).Return(nil, errors.New("rate limited"))
+
+		c, err := composite.NewClient(composite.Fallback, logging.Nop(),
+			composite.Provider{Name: "first", Client: first},
+			composite.Provider{Name: "second", Client: second})
+		require.NoError(t, err)
+
+		_, err = c.GetCoins(context.Background(), titles, quotes)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "first: outage")
+		assert.Contains(t, err.Error(), "second: rate limited")
+	})
+}
+
+func TestClient_Median(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a, b, c, d := mock.NewMockClient(ctrl), mock.NewMockClient(ctrl), mock.NewMockClient(ctrl), mock.NewMockClient(ctrl)
+	a.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entities.Coin{coin("BTC", "USD", "100"), coin("ETH", "USD", "2540.1")}, nil)
+	b.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entities.Coin{coin("BTC", "USD", "104"), coin("ETH", "USD", "2540.2")}, nil)
+	c.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entities.Coin{coin("BTC", "USD", "101")}, nil)
+	d.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("outage"))
+
+	client, err := composite.NewClient(composite.Median, logging.Nop(),
+		composite.Provider{Name: "a", Client: a},
+		composite.Provider{Name: "b", Client: b},
+		composite.Provider{Name: "c", Client: c},
+		composite.Provider{Name: "d", Client: d})
+	require.NoError(t, err)
+
+	coins, err := client.GetCoins(context.Background(), []string{"BTC", "ETH"}, []string{"USD"})
+	require.NoError(t, err)
+	require.Len(t, coins, 2)
+
+	assert.Equal(t, "BTC", coins[0].Title)
+	assert.Equal(t, "101", coins[0].Price.String())
+	assert.Equal(t, "a,b,c", coins[0].Provider)
+
+	assert.Equal(t, "ETH", coins[1].Title)
+	assert.Equal(t, "2540.15", coins[1].Price.String())
+	assert.Equal(t, "a,b", coins[1].Provider)
+}
+
+func TestClient_SkipsOpenBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	breaker := resilience.NewBreaker(1, time.Minute)
+	breaker.Failure()
+
+	first, second := mock.NewMockClient(ctrl), mock.NewMockClient(ctrl)
+	second.EXPECT().GetCoins(gomock.Any(), []string{"BTC"}, []string{"USD"}).
+		Return([]entities.Coin{coin("BTC", "USD", "1")}, nil)
+
+	c, err := composite.NewClient(composite.Fallback, logging.Nop(),
+		composite.Provider{Name: "first", Client: first, Breaker: breaker},
+		composite.Provider{Name: "second", Client: second, Breaker: resilience.NewBreaker(1, time.Minute)})
+	require.NoError(t, err)
+
+	coins, err := c.GetCoins(context.Background(), []string{"BTC"}, []string{"USD"})
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	assert.Equal(t, "second", coins[0].Provider)
+	assert.Equal(t, map[string]resilience.State{"first": resilience.Open, "second": resilience.Closed}, c.States())
+}
@@ -0,0 +1,133 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// assets maps common tickers to Kraken asset codes.
+var assets = map[string]string{"BTC": "XBT", "DOGE": "XDG"}
+
+// Client reads the Kraken public ticker (GET /0/public/Ticker).
+type Client struct {
+	client *http.Client
+	url    *url.URL
+	quotes []string
+}
+
+func NewClient(rawURL string, quotes []string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("url: %s", rawURL))
+	}
+	if len(quotes) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "quotes are empty")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{client: httpClient, url: u, quotes: quotes}, nil
+}
+
+type tickerResponse struct {
+	Error  []string `json:"error"`
+	Result map[string]struct {
+		// C is the last trade closed: [price, lot volume].
+		C []string `json:"c"`
+	} `json:"result"`
+}
+
+// GetCoins issues one request per title/quote pair because Kraken keys the
+// result by its own pair names (XXBTZUSD for XBTUSD). Unknown pairs are
+// skipped.
+func (c *Client) GetCoins(ctx context.Context, titles []string, quotes []string) ([]entities.Coin, error) {
+	if len(quotes) == 0 {
+		quotes = c.quotes
+	}
+
+	var coins []entities.Coin
+	for _, t := range titles {
+		for _, q := range quotes {
+			coin, err := c.getTicker(ctx, t, q)
+			if err != nil {
+				return nil, err
+			}
+			if coin != nil {
+				coins = append(coins, *coin)
+			}
+		}
+	}
+
+	return coins, nil
+}
+
+func asset(symbol string) string {
+	if a, ok := assets[symbol]; ok {
+		return a
+	}
+	return symbol
+}
+
+func (c *Client) getTicker(ctx context.Context, title, quote string) (*entities.Coin, error) {
+	pair := asset(title) + asset(quote)
+
+	u := *c.url
+	qs := u.Query()
+	qs.Set("pair", pair)
+	u.RawQuery = qs.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Couldn't form a request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Couldn't get %s", pair))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Status Error: %s\n", resp.Status)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Couldn't count the response")
+	}
+
+	var ticker tickerResponse
+	if err := json.Unmarshal(bodyBytes, &ticker); err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("pair: %s", pair))
+	}
+	if len(ticker.Error) > 0 {
+		if strings.Contains(strings.Join(ticker.Error, ","), "Unknown asset pair") {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Kraken Error: %s", strings.Join(ticker.Error, ", "))
+	}
+
+	for _, t := range ticker.Result {
+		if len(t.C) == 0 {
+			break
+		}
+		price, err := decimal.NewFromString(t.C[0])
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("price of %s: %s", pair, t.C[0]))
+		}
+		return entities.NewCoin(title, quote, price, time.Now())
+	}
+
+	return nil, nil
+}
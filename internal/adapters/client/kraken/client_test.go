@@ -0,0 +1,54 @@
+package kraken_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"currency/internal/adapters/client/kraken"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetCoins(t *testing.T) {
+	t.Run("successful response", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("pair") {
+			case "XBTUSD":
+				rw.Write([]byte(`{"error": [], "result": {"XXBTZUSD": {"c": ["103512.40000", "0.00100000"]}}}`))
+			case "XBTRUB":
+				rw.Write([]byte(`{"error": ["EQuery:Unknown asset pair"]}`))
+			default:
+				t.Errorf("unexpected pair %s", r.URL.Query().Get("pair"))
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := kraken.NewClient(testServer.URL, []string{"USD", "RUB"}, nil)
+		require.NoError(t, err)
+
+		coins, err := client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+
+		require.Len(t, coins, 1)
+		assert.Equal(t, "BTC", coins[0].Title)
+		assert.Equal(t, "USD", coins[0].Quote)
+		assert.Equal(t, "103512.4", coins[0].Price.String())
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte(`{"error": ["EService:Unavailable"]}`))
+		}))
+		defer testServer.Close()
+
+		client, err := kraken.NewClient(testServer.URL, []string{"USD"}, nil)
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "EService:Unavailable")
+	})
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"currency/internal/adapters/client/binance"
+	"currency/internal/adapters/client/coinbase"
+	"currency/internal/adapters/client/coindesk"
+	"currency/internal/adapters/client/composite"
+	"currency/internal/adapters/client/kraken"
+	"currency/internal/adapters/client/resilience"
+	"currency/internal/entities"
+	"currency/internal/metrics"
+	"currency/internal/usecases"
+
+	"github.com/pkg/errors"
+)
+
+// ProviderConfig is one entry of externalAPI.providers in config.yaml.
+type ProviderConfig struct {
+	Name string `mapstructure:"name"`
+	URL  string `mapstructure:"url"`
+}
+
+// Factory builds a provider client. quotes are its default tsyms, httpClient
+// carries the timeout, retry and circuit breaker settings.
+type Factory func(url string, quotes []string, httpClient *http.Client) (usecases.Client, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{
+		"cryptocompare": func(url string, quotes []string, httpClient *http.Client) (usecases.Client, error) {
+			return coindesk.NewClient(url, quotes, httpClient)
+		},
+		"binance": func(url string, quotes []string, httpClient *http.Client) (usecases.Client, error) {
+			return binance.NewClient(url, quotes, httpClient)
+		},
+		"coinbase": func(url string, quotes []string, httpClient *http.Client) (usecases.Client, error) {
+			return coinbase.NewClient(url, quotes, httpClient)
+		},
+		"kraken": func(url string, quotes []string, httpClient *http.Client) (usecases.Client, error) {
+			return kraken.NewClient(url, quotes, httpClient)
+		},
+	}
+)
+
+// Register makes a provider available to New under name, replacing any
+// provider registered before with the same name.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = factory
+}
+
+// Providers returns the registered provider names.
+func Providers() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return names()
+}
+
+func names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds every configured provider and combines them with strategy.
+// Providers keep the order of configs, which is their fallback priority.
+// Each provider gets its own circuit breaker configured by httpConfig and is
+// instrumented with metrics.
+func New(strategy string, configs []ProviderConfig, quotes []string, httpConfig resilience.Config, logger *slog.Logger) (*composite.Client, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	providers := make([]composite.Provider, 0, len(configs))
+	for _, cfg := range configs {
+		factory, ok := factories[cfg.Name]
+		if !ok {
+			return nil, errors.Wrap(entities.ErrInvalidParams,
+				fmt.Sprintf("unknown provider %s, expected one of: %s", cfg.Name, strings.Join(names(), ", ")))
+		}
+		breaker := resilience.NewBreaker(httpConfig.FailureThreshold, httpConfig.OpenTimeout)
+		c, err := factory(cfg.URL, quotes, resilience.NewHTTPClient(httpConfig, breaker))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("create provider %s failed", cfg.Name))
+		}
+		providers = append(providers, composite.Provider{Name: cfg.Name, Client: metrics.InstrumentClient(cfg.Name, c), Breaker: breaker})
+	}
+
+	if strategy == "" {
+		strategy = string(composite.Fallback)
+	}
+	return composite.NewClient(composite.Strategy(strategy), logger, providers...)
+}
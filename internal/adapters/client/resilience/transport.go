@@ -0,0 +1,227 @@
+package resilience
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Config is externalAPI.http in config.yaml.
+type Config struct {
+	Timeout          time.Duration `mapstructure:"timeout"`
+	MaxRetries       int           `mapstructure:"maxRetries"`
+	BaseDelay        time.Duration `mapstructure:"baseDelay"`
+	MaxDelay         time.Duration `mapstructure:"maxDelay"`
+	FailureThreshold int           `mapstructure:"failureThreshold"`
+	OpenTimeout      time.Duration `mapstructure:"openTimeout"`
+}
+
+// DefaultConfig is used for every zero field of a Config but MaxRetries:
+// zero MaxRetries disables retries.
+var DefaultConfig = Config{
+	Timeout:          10 * time.Second,
+	MaxRetries:       3,
+	BaseDelay:        200 * time.Millisecond,
+	MaxDelay:         5 * time.Second,
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+}
+
+func (c Config) withDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = DefaultConfig.Timeout
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	if c.BaseDelay == 0 {
+		c.BaseDelay = DefaultConfig.BaseDelay
+	}
+	if c.MaxDelay == 0 {
+		c.MaxDelay = DefaultConfig.MaxDelay
+	}
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = DefaultConfig.FailureThreshold
+	}
+	if c.OpenTimeout == 0 {
+		c.OpenTimeout = DefaultConfig.OpenTimeout
+	}
+	return c
+}
+
+// Transport retries requests failing with a network error, 429 or 5xx using
+// exponential backoff with full jitter, honouring Retry-After, and guards the
+// upstream with a Breaker. Requests must be replayable, i.e. have no body or
+// a GetBody.
+type Transport struct {
+	next    http.RoundTripper
+	breaker *Breaker
+	retries int
+	base    time.Duration
+	max     time.Duration
+	sleep   func(ctx context.Context, d time.Duration) error
+	jitter  func(d time.Duration) time.Duration
+	timeNow func() time.Time
+}
+
+// NewHTTPClient returns an http.Client with cfg.Timeout per attempt whose
+// transport retries and reports to breaker.
+func NewHTTPClient(cfg Config, breaker *Breaker) *http.Client {
+	cfg = cfg.withDefaults()
+	return &http.Client{
+		Transport: NewTransport(http.DefaultTransport, cfg, breaker),
+	}
+}
+
+func NewTransport(next http.RoundTripper, cfg Config, breaker *Breaker) *Transport {
+	cfg = cfg.withDefaults()
+	return &Transport{
+		next:    &timeoutTransport{next: next, timeout: cfg.Timeout},
+		breaker: breaker,
+		retries: cfg.MaxRetries,
+		base:    cfg.BaseDelay,
+		max:     cfg.MaxDelay,
+		sleep:   sleep,
+		jitter: func(d time.Duration) time.Duration {
+			return time.Duration(rand.Int63n(int64(d) + 1))
+		},
+		timeNow: time.Now,
+	}
+}
+
+// RoundTrip asks the breaker once and reports it one outcome after the
+// retries; a request cancelled by its caller is neither a success nor a
+// failure.
+func (t *Transport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	if t.breaker == nil {
+		return t.retry(req)
+	}
+	if !t.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err = t.retry(req)
+	switch {
+	case err == nil && !retryable(resp.StatusCode):
+		t.breaker.Success()
+	case req.Context().Err() != nil:
+		t.breaker.Cancel()
+	default:
+		t.breaker.Failure()
+	}
+	return resp, err
+}
+
+func (t *Transport) retry(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err == nil && !retryable(resp.StatusCode) {
+			return resp, nil
+		}
+
+		if attempt >= t.retries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if after, ok := t.retryAfter(resp); ok {
+				if after > t.max {
+					return resp, nil
+				}
+				if after > delay {
+					delay = after
+				}
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff is a random delay in [0, min(max, base*2^attempt)].
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.base << attempt
+	if d <= 0 || d > t.max {
+		d = t.max
+	}
+	return t.jitter(d)
+}
+
+// retryAfter parses the Retry-After header, either delay-seconds or an
+// HTTP date.
+func (t *Transport) retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		d := at.Sub(t.timeNow())
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// timeoutTransport bounds a single attempt, including reading the body.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,204 @@
+package resilience_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"currency/internal/adapters/client/coindesk"
+	"currency/internal/adapters/client/resilience"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fast = resilience.Config{
+	Timeout:          time.Second,
+	MaxRetries:       3,
+	BaseDelay:        time.Millisecond,
+	MaxDelay:         10 * time.Millisecond,
+	FailureThreshold: 100,
+	OpenTimeout:      time.Minute,
+}
+
+// flaky answers with statuses in order and then with a BTC price.
+func flaky(t *testing.T, calls *int32, statuses ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		if int(n) <= len(statuses) {
+			if statuses[n-1] == http.StatusTooManyRequests {
+				rw.Header().Set("Retry-After", "0")
+			}
+			rw.WriteHeader(statuses[n-1])
+			return
+		}
+		rw.Write([]byte(`{"BTC": {"RUB": 8398290.1}}`))
+	}))
+}
+
+func TestTransport_Retry(t *testing.T) {
+	t.Run("retries 429 and 5xx", func(t *testing.T) {
+		var calls int32
+		testServer := flaky(t, &calls, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable)
+		defer testServer.Close()
+
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(fast, nil))
+		require.NoError(t, err)
+
+		coins, err := client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+		assert.Len(t, coins, 1)
+		assert.EqualValues(t, 4, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		var calls int32
+		testServer := flaky(t, &calls, 500, 500, 500, 500, 500)
+		defer testServer.Close()
+
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(fast, nil))
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Status Error: 500 Internal Server Error")
+		assert.EqualValues(t, 4, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("zero max retries disables retries", func(t *testing.T) {
+		var calls int32
+		testServer := flaky(t, &calls, 500)
+		defer testServer.Close()
+
+		cfg := fast
+		cfg.MaxRetries = 0
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(cfg, nil))
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("does not retry 4xx", func(t *testing.T) {
+		var calls int32
+		testServer := flaky(t, &calls, http.StatusBadRequest)
+		defer testServer.Close()
+
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(fast, nil))
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Retry-After beyond max delay is not waited for", func(t *testing.T) {
+		var calls int32
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			rw.Header().Set("Retry-After", "120")
+			rw.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer testServer.Close()
+
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(fast, nil))
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "429")
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Retry-After is respected", func(t *testing.T) {
+		var calls int32
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				rw.Header().Set("Retry-After", "1")
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			rw.Write([]byte(`{"BTC": {"RUB": 8398290.1}}`))
+		}))
+		defer testServer.Close()
+
+		cfg := fast
+		cfg.MaxDelay = 2 * time.Second
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(cfg, nil))
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("timeout per attempt", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+		}))
+		defer testServer.Close()
+
+		cfg := fast
+		cfg.Timeout = 10 * time.Millisecond
+		cfg.MaxRetries = 1
+		client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(cfg, nil))
+		require.NoError(t, err)
+
+		_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "context deadline exceeded")
+	})
+}
+
+func TestTransport_Breaker(t *testing.T) {
+	var calls int32
+	testServer := flaky(t, &calls, 500, 500, 500, 500, 500, 500)
+	defer testServer.Close()
+
+	cfg := fast
+	cfg.MaxRetries = 1
+	cfg.FailureThreshold = 2
+	breaker := resilience.NewBreaker(cfg.FailureThreshold, cfg.OpenTimeout)
+
+	client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(cfg, breaker))
+	require.NoError(t, err)
+
+	_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+	require.Error(t, err)
+	assert.Equal(t, resilience.Closed, breaker.State(), "retries of a request are one failure")
+
+	_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+	require.Error(t, err)
+	assert.Equal(t, resilience.Open, breaker.State())
+
+	_, err = client.GetCoins(context.Background(), []string{"BTC"}, nil)
+	require.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	assert.EqualValues(t, 4, atomic.LoadInt32(&calls))
+}
+
+func TestTransport_BreakerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer testServer.Close()
+
+	cfg := fast
+	cfg.FailureThreshold = 1
+	breaker := resilience.NewBreaker(cfg.FailureThreshold, cfg.OpenTimeout)
+
+	client, err := coindesk.NewClient(testServer.URL, []string{"RUB"}, resilience.NewHTTPClient(cfg, breaker))
+	require.NoError(t, err)
+
+	_, err = client.GetCoins(ctx, []string{"BTC"}, nil)
+	require.Error(t, err)
+	assert.Equal(t, resilience.Closed, breaker.State())
+}
@@ -0,0 +1,8 @@
+package webhook
+
+import "net"
+
+// AllowInternal lets n deliver to the httptest servers on the loopback.
+func AllowInternal(n *Notifier) {
+	n.blocked = func(net.IP) bool { return false }
+}
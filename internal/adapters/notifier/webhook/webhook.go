@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"currency/internal/entities"
+	"currency/pkg/dto"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the request
+// body keyed with the alert secret.
+const SignatureHeader = "X-Signature-256"
+
+const defaultTimeout = 10 * time.Second
+
+// Notifier posts fired alerts as JSON to their webhook URL.
+type Notifier struct {
+	httpClient *http.Client
+	// blocked reports the addresses Notify must not connect to.
+	blocked func(ip net.IP) bool
+}
+
+// NewNotifier returns a Notifier whose requests time out after timeout, 10s
+// if it is zero. It doesn't follow redirects and refuses to connect to an
+// entities.InternalIP, whatever the webhook host resolves to at delivery.
+func NewNotifier(timeout time.Duration) (*Notifier, error) {
+	if timeout < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("timeout: %s", timeout))
+	}
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	n := &Notifier{blocked: entities.InternalIP}
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second, Control: n.control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Через прокси проверялся бы адрес прокси, а не вебхука.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	n.httpClient = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return n, nil
+}
+
+// control runs after the webhook host is resolved and before connecting, so
+// a name pointing at an internal address is refused too.
+func (n *Notifier) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("Invalid webhook address: %s", address))
+	}
+	if ip := net.ParseIP(host); ip == nil || n.blocked(ip) {
+		return errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("Webhook resolves to an internal address: %s", host))
+	}
+	return nil
+}
+
+// Notify makes one POST of the alert event. Any status but 2xx is an error.
+func (n *Notifier) Notify(ctx context.Context, alert entities.Alert, coin entities.Coin) (int, error) {
+	body, err := json.Marshal(dto.AlertEventDTO{
+		AlertID:   alert.ID,
+		Title:     alert.Title,
+		Quote:     alert.Quote,
+		Condition: string(alert.Condition),
+		Threshold: alert.Threshold,
+		Price:     coin.Price,
+		PriceTime: coin.CreateTime.Format(time.RFC3339),
+		FiredAt:   alert.LastFiredAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return 0, errors.Wrap(entities.ErrInternalServer, err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, alert.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("Invalid webhook url: %s", alert.WebhookURL))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(alert.Secret, body))
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("Couldn't deliver alert %d", alert.ID))
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Status Error: %s", resp.Status))
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the SignatureHeader value of body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
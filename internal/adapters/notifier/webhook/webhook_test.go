@@ -0,0 +1,149 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"currency/internal/adapters/notifier/webhook"
+	"currency/internal/entities"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	firedAt := time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+	alert := entities.Alert{
+		ID:          7,
+		Title:       "BTC",
+		Quote:       "USD",
+		Condition:   entities.AlertAbove,
+		Threshold:   decimal.NewFromInt(100000),
+		Secret:      "secret",
+		LastFiredAt: firedAt,
+	}
+	coin := entities.Coin{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(105000), CreateTime: firedAt.Add(-time.Second)}
+
+	t.Run("signed post", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, webhook.Sign("secret", body), r.Header.Get(webhook.SignatureHeader))
+			assert.JSONEq(t, `{
+				"alert_id": 7,
+				"title": "BTC",
+				"quote": "USD",
+				"condition": "above",
+				"threshold": "100000",
+				"price": "105000",
+				"price_time": "2025-05-17T11:59:59Z",
+				"fired_at": "2025-05-17T12:00:00Z"
+			}`, string(body))
+
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer testServer.Close()
+
+		n, err := webhook.NewNotifier(0)
+		require.NoError(t, err)
+		webhook.AllowInternal(n)
+
+		alert := alert
+		alert.WebhookURL = testServer.URL
+		status, err := n.Notify(context.Background(), alert, coin)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadGateway)
+		}))
+		defer testServer.Close()
+
+		n, err := webhook.NewNotifier(0)
+		require.NoError(t, err)
+		webhook.AllowInternal(n)
+
+		alert := alert
+		alert.WebhookURL = testServer.URL
+		status, err := n.Notify(context.Background(), alert, coin)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, status)
+		assert.Contains(t, err.Error(), "Status Error: 502 Bad Gateway")
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		n, err := webhook.NewNotifier(0)
+		require.NoError(t, err)
+		webhook.AllowInternal(n)
+
+		alert := alert
+		alert.WebhookURL = "http://invalid-url"
+		status, err := n.Notify(context.Background(), alert, coin)
+		require.Error(t, err)
+		assert.Zero(t, status)
+	})
+}
+
+func TestNotifier_Guard(t *testing.T) {
+	alert := entities.Alert{ID: 7, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove}
+	coin := entities.Coin{Title: "BTC", Quote: "USD"}
+
+	t.Run("internal address", func(t *testing.T) {
+		hit := false
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			hit = true
+		}))
+		defer testServer.Close()
+
+		n, err := webhook.NewNotifier(0)
+		require.NoError(t, err)
+
+		alert := alert
+		alert.WebhookURL = testServer.URL
+		status, err := n.Notify(context.Background(), alert, coin)
+		assert.ErrorIs(t, err, entities.ErrInvalidParams)
+		assert.Zero(t, status)
+		assert.False(t, hit)
+	})
+
+	t.Run("redirect is not followed", func(t *testing.T) {
+		hit := false
+		target := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			hit = true
+		}))
+		defer target.Close()
+		testServer := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+		defer testServer.Close()
+
+		n, err := webhook.NewNotifier(0)
+		require.NoError(t, err)
+		webhook.AllowInternal(n)
+
+		alert := alert
+		alert.WebhookURL = testServer.URL
+		status, err := n.Notify(context.Background(), alert, coin)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusTemporaryRedirect, status)
+		assert.False(t, hit)
+	})
+
+	t.Run("negative timeout", func(t *testing.T) {
+		_, err := webhook.NewNotifier(-time.Second)
+		assert.ErrorIs(t, err, entities.ErrInvalidParams)
+	})
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13", webhook.Sign("secret", []byte("{}")))
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// CreateAlert stores alert and sets its ID and CreateTime.
+func (s *Storage) CreateAlert(_ context.Context, alert *entities.Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastAlertID++
+	alert.ID = s.lastAlertID
+	alert.CreateTime = time.Now().UTC()
+	s.alerts[alert.ID] = *alert
+	return nil
+}
+
+func (s *Storage) GetAlert(_ context.Context, id int64) (*entities.Alert, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	alert, ok := s.alerts[id]
+	if !ok {
+		return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to get alert: %d", id))
+	}
+	return &alert, nil
+}
+
+func (s *Storage) GetAlerts(_ context.Context) ([]entities.Alert, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var alerts []entities.Alert
+	for _, alert := range s.alerts {
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].ID < alerts[j].ID })
+	return alerts, nil
+}
+
+// UpdateAlert overwrites every field of the alert with alert.ID but CreateTime.
+func (s *Storage) UpdateAlert(_ context.Context, alert *entities.Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.alerts[alert.ID]
+	if !ok {
+		return errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to update alert: %d", alert.ID))
+	}
+	updated := *alert
+	updated.CreateTime = stored.CreateTime
+	s.alerts[alert.ID] = updated
+	return nil
+}
+
+// DeleteAlert deletes the alert together with its delivery log.
+func (s *Storage) DeleteAlert(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.alerts[id]; !ok {
+		return errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to delete alert: %d", id))
+	}
+	delete(s.alerts, id)
+
+	deliveries := s.deliveries[:0]
+	for _, d := range s.deliveries {
+		if d.AlertID != id {
+			deliveries = append(deliveries, d)
+		}
+	}
+	s.deliveries = deliveries
+	return nil
+}
+
+func (s *Storage) SetAlertState(_ context.Context, id int64, lastPrice decimal.Decimal, lastFiredAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if alert, ok := s.alerts[id]; ok {
+		alert.LastPrice, alert.LastFiredAt = lastPrice, lastFiredAt
+		s.alerts[id] = alert
+	}
+	return nil
+}
+
+// StoreDelivery stores delivery and sets its ID.
+func (s *Storage) StoreDelivery(_ context.Context, delivery *entities.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastDeliveryID++
+	delivery.ID = s.lastDeliveryID
+	s.deliveries = append(s.deliveries, *delivery)
+	return nil
+}
+
+// GetDeliveries returns up to limit deliveries of the alert, newest first.
+func (s *Storage) GetDeliveries(_ context.Context, alertID int64, limit int) ([]entities.Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deliveries []entities.Delivery
+	for i := len(s.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if s.deliveries[i].AlertID == alertID {
+			deliveries = append(deliveries, s.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
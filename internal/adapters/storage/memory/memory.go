@@ -0,0 +1,306 @@
+// Package memory keeps prices, symbols and alerts in the process memory.
+// It is meant for development and tests: nothing survives a restart and
+// retention, migrations and leader election are not supported.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/metrics"
+	"currency/internal/usecases"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type pair struct {
+	title string
+	quote string
+}
+
+type Storage struct {
+	mu sync.RWMutex
+	// coins are ordered by ID.
+	coins  []entities.Coin
+	latest map[pair]entities.Coin
+	lastID int64
+
+	symbols map[string]entities.Symbol
+
+	alerts         map[int64]entities.Alert
+	deliveries     []entities.Delivery
+	lastAlertID    int64
+	lastDeliveryID int64
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		latest:  make(map[pair]entities.Coin),
+		symbols: make(map[string]entities.Symbol),
+		alerts:  make(map[int64]entities.Alert),
+	}
+}
+
+// Store appends coins and sets their ID. A price older than the latest one
+// of its pair doesn't replace it.
+func (s *Storage) Store(_ context.Context, coins []entities.Coin) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range coins {
+		s.lastID++
+		coins[i].ID = s.lastID
+		coin := coins[i]
+		s.coins = append(s.coins, coin)
+
+		key := pair{coin.Title, coin.Quote}
+		if latest, ok := s.latest[key]; !ok || !coin.CreateTime.Before(latest.CreateTime) {
+			s.latest[key] = coin
+		}
+		metrics.CoinsStored.WithLabelValues(coin.Title).Inc()
+	}
+	return nil
+}
+
+// GetAfter returns up to limit coins stored after the coin with the given
+// id, oldest first. Empty titles match every title.
+func (s *Storage) GetAfter(_ context.Context, id int64, titles []string, limit int, options ...usecases.Option) ([]entities.Coin, error) {
+	opts := newOptions(options)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var coins []entities.Coin
+	start := sort.Search(len(s.coins), func(i int) bool { return s.coins[i].ID > id })
+	for _, coin := range s.coins[start:] {
+		if len(coins) >= limit {
+			break
+		}
+		if (len(titles) == 0 || contains(titles, coin.Title)) && matchQuote(opts, coin.Quote) {
+			coins = append(coins, coin)
+		}
+	}
+	return coins, nil
+}
+
+// LastID returns the id of the latest stored coin, 0 if none is.
+func (s *Storage) LastID(context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastID, nil
+}
+
+// Get returns one coin per stored quote of every title, in the order of
+// titles and then by quote. An empty quotes option matches every quote. A
+// title without any price in the quotes is reported as entities.ErrNotFound,
+// one without prices in the range is left out.
+func (s *Storage) Get(_ context.Context, titles []string, options ...usecases.Option) ([]entities.Coin, error) {
+	if len(titles) == 0 {
+		return nil, nil
+	}
+	opts := newOptions(options)
+	from, to := opts.Range(time.Now())
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// без агрегата и границ нужна только последняя цена, как latest_prices в postgres.
+	latest := opts.FuncType != usecases.Max && opts.FuncType != usecases.Min && opts.FuncType != usecases.Avg &&
+		from.IsZero() && to.IsZero()
+
+	var coins []entities.Coin
+	for _, title := range titles {
+		var found []entities.Coin
+		if latest {
+			found = s.getLatest(title, opts)
+		} else {
+			found = s.aggregate(title, opts, from, to)
+		}
+		if len(found) == 0 && (latest || len(s.getLatest(title, opts)) == 0) {
+			return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to get coin: %s", title))
+		}
+		coins = append(coins, found...)
+	}
+	return coins, nil
+}
+
+func (s *Storage) getLatest(title string, opts *usecases.Options) []entities.Coin {
+	var coins []entities.Coin
+	for key, coin := range s.latest {
+		if key.title == title && matchQuote(opts, key.quote) {
+			coins = append(coins, coin)
+		}
+	}
+	sort.Slice(coins, func(i, j int) bool { return coins[i].Quote < coins[j].Quote })
+	return coins
+}
+
+// aggregate applies opts.FuncType, or picks the last price, per quote of
+// the coins of title created in [from, to].
+func (s *Storage) aggregate(title string, opts *usecases.Options, from, to time.Time) []entities.Coin {
+	type state struct {
+		last     entities.Coin
+		min, max decimal.Decimal
+		sum      decimal.Decimal
+		count    int64
+		first    time.Time
+	}
+	byQuote := make(map[string]*state)
+	for _, coin := range s.coins {
+		if coin.Title != title || !matchQuote(opts, coin.Quote) || !inRange(coin.CreateTime, from, to) {
+			continue
+		}
+		st, ok := byQuote[coin.Quote]
+		if !ok {
+			byQuote[coin.Quote] = &state{last: coin, min: coin.Price, max: coin.Price, sum: coin.Price, count: 1, first: coin.CreateTime}
+			continue
+		}
+		if !coin.CreateTime.Before(st.last.CreateTime) {
+			st.last = coin
+		}
+		if coin.CreateTime.Before(st.first) {
+			st.first = coin.CreateTime
+		}
+		st.min = decimal.Min(st.min, coin.Price)
+		st.max = decimal.Max(st.max, coin.Price)
+		st.sum = st.sum.Add(coin.Price)
+		st.count++
+	}
+
+	coins := make([]entities.Coin, 0, len(byQuote))
+	for quote, st := range byQuote {
+		coin := entities.Coin{Title: title, Quote: quote, CreateTime: st.last.CreateTime, From: st.first}
+		switch opts.FuncType {
+		case usecases.Max:
+			coin.Price = st.max
+		case usecases.Min:
+			coin.Price = st.min
+		case usecases.Avg:
+			coin.Price = st.sum.Div(decimal.NewFromInt(st.count))
+		default:
+			coin = entities.Coin{Title: title, Quote: quote, Price: st.last.Price, CreateTime: st.last.CreateTime, Provider: st.last.Provider}
+		}
+		coins = append(coins, coin)
+	}
+	sort.Slice(coins, func(i, j int) bool { return coins[i].Quote < coins[j].Quote })
+	return coins
+}
+
+// GetCandles returns one OHLC candle per bucket, title and quote, ordered by
+// title as in titles, quote and bucket start. Buckets are aligned as in
+// postgres, so both storages return the same candles.
+func (s *Storage) GetCandles(_ context.Context, titles []string, interval time.Duration, options ...usecases.Option) ([]entities.Candle, error) {
+	if len(titles) == 0 {
+		return nil, nil
+	}
+	if interval <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("Unable to get candles: %s", strings.Join(titles, ",")))
+	}
+	opts := newOptions(options)
+	from, to := opts.Range(time.Now())
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var candles []entities.Candle
+	for _, title := range titles {
+		type key struct {
+			quote string
+			start time.Time
+		}
+		byBucket := make(map[key]*entities.Candle)
+		// coins are ordered by ID, so equal times keep the first stored as open.
+		first := make(map[key]time.Time)
+		last := make(map[key]time.Time)
+		for _, coin := range s.coins {
+			if coin.Title != title || !matchQuote(opts, coin.Quote) || !inRange(coin.CreateTime, from, to) {
+				continue
+			}
+			k := key{coin.Quote, usecases.CandleStart(coin.CreateTime, interval)}
+			c, ok := byBucket[k]
+			if !ok {
+				byBucket[k] = &entities.Candle{
+					Title: title, Quote: coin.Quote, Start: k.start, Interval: interval,
+					Open: coin.Price, High: coin.Price, Low: coin.Price, Close: coin.Price,
+				}
+				first[k], last[k] = coin.CreateTime, coin.CreateTime
+				continue
+			}
+			if coin.CreateTime.Before(first[k]) {
+				c.Open, first[k] = coin.Price, coin.CreateTime
+			}
+			if !coin.CreateTime.Before(last[k]) {
+				c.Close, last[k] = coin.Price, coin.CreateTime
+			}
+			c.High = decimal.Max(c.High, coin.Price)
+			c.Low = decimal.Min(c.Low, coin.Price)
+		}
+
+		found := make([]entities.Candle, 0, len(byBucket))
+		for _, c := range byBucket {
+			found = append(found, *c)
+		}
+		sort.Slice(found, func(i, j int) bool {
+			if found[i].Quote != found[j].Quote {
+				return found[i].Quote < found[j].Quote
+			}
+			return found[i].Start.Before(found[j].Start)
+		})
+		candles = append(candles, found...)
+	}
+	return candles, nil
+}
+
+// GetUpdates returns the time of the latest price of every pair.
+func (s *Storage) GetUpdates(_ context.Context) ([]entities.SymbolStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var updates []entities.SymbolStatus
+	for key, coin := range s.latest {
+		updates = append(updates, entities.SymbolStatus{Title: key.title, Quote: key.quote, UpdateTime: coin.CreateTime})
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		if updates[i].Title != updates[j].Title {
+			return updates[i].Title < updates[j].Title
+		}
+		return updates[i].Quote < updates[j].Quote
+	})
+	return updates, nil
+}
+
+func (s *Storage) Ping(context.Context) error {
+	return nil
+}
+
+func newOptions(options []usecases.Option) *usecases.Options {
+	opts := &usecases.Options{}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts
+}
+
+func matchQuote(opts *usecases.Options, quote string) bool {
+	return len(opts.Quotes) == 0 || contains(opts.Quotes, quote)
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange reports whether t is in [from, to]; a zero bound is open.
+func inRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
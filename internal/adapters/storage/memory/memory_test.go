@@ -0,0 +1,45 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"currency/internal/adapters/storage/memory"
+	"currency/internal/adapters/storage/storagetest"
+	"currency/internal/entities"
+	"currency/internal/usecases"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(*testing.T) storagetest.Storage { return memory.NewStorage() })
+}
+
+func TestStorage_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	s := memory.NewStorage()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				coin := entities.Coin{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(int64(j)), CreateTime: time.Now()}
+				assert.NoError(t, s.Store(ctx, []entities.Coin{coin}))
+				_, err := s.Get(ctx, []string{"BTC"}, usecases.WithAvgFunc())
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	coins, err := s.GetAfter(ctx, 0, nil, 1000)
+	require.NoError(t, err)
+	assert.Len(t, coins, 400)
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+// AddSymbol stores symbol and sets its CreateTime.
+func (s *Storage) AddSymbol(_ context.Context, symbol *entities.Symbol) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.symbols[symbol.Title]; ok {
+		return errors.Wrap(entities.ErrConflict, fmt.Sprintf("Symbol is already tracked: %s", symbol.Title))
+	}
+	symbol.CreateTime = time.Now().UTC()
+	s.symbols[symbol.Title] = *symbol
+	return nil
+}
+
+func (s *Storage) GetSymbols(_ context.Context) ([]entities.Symbol, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var symbols []entities.Symbol
+	for _, symbol := range s.symbols {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].Title < symbols[j].Title })
+	return symbols, nil
+}
+
+func (s *Storage) SetSymbolPaused(_ context.Context, title string, paused bool) (*entities.Symbol, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	symbol, ok := s.symbols[title]
+	if !ok {
+		return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to update symbol: %s", title))
+	}
+	symbol.Paused = paused
+	s.symbols[title] = symbol
+	return &symbol, nil
+}
+
+func (s *Storage) DeleteSymbol(_ context.Context, title string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.symbols[title]; !ok {
+		return errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to delete symbol: %s", title))
+	}
+	delete(s.symbols, title)
+	return nil
+}
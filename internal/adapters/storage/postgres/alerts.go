@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/tracing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const alertColumns = `id, title, quote, condition, threshold, cooldown_seconds, webhook_url, secret, last_price, last_fired_at, created_at`
+
+// CreateAlert inserts alert and sets its ID and CreateTime.
+func (s *Storage) CreateAlert(ctx context.Context, alert *entities.Alert) (err error) {
+	ctx, span := startSpan(ctx, "CreateAlert")
+	defer tracing.End(span, &err)
+
+	query := `INSERT INTO alerts (title, quote, condition, threshold, cooldown_seconds, webhook_url, secret, last_price, last_fired_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at;`
+
+	err = s.db.QueryRow(ctx, query, alert.Title, alert.Quote, string(alert.Condition), alert.Threshold,
+		int64(alert.Cooldown/time.Second), alert.WebhookURL, alert.Secret, alert.LastPrice, nullTime(alert.LastFiredAt),
+	).Scan(&alert.ID, &alert.CreateTime)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Alert was not added")
+	}
+	return nil
+}
+
+func (s *Storage) GetAlert(ctx context.Context, id int64) (_ *entities.Alert, err error) {
+	ctx, span := startSpan(ctx, "GetAlert")
+	defer tracing.End(span, &err)
+
+	query := `SELECT ` + alertColumns + ` FROM alerts WHERE id = $1;`
+
+	alert, err := scanAlert(s.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to get alert: %d", id))
+	}
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get alert: %d", id))
+	}
+	return alert, nil
+}
+
+func (s *Storage) GetAlerts(ctx context.Context) (_ []entities.Alert, err error) {
+	ctx, span := startSpan(ctx, "GetAlerts")
+	defer tracing.End(span, &err)
+
+	query := `SELECT ` + alertColumns + ` FROM alerts ORDER BY id;`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get alerts")
+	}
+	defer rows.Close()
+
+	var alerts []entities.Alert
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get alerts")
+		}
+		alerts = append(alerts, *alert)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get alerts")
+	}
+
+	return alerts, nil
+}
+
+// UpdateAlert overwrites every field of the alert with alert.ID but CreateTime.
+func (s *Storage) UpdateAlert(ctx context.Context, alert *entities.Alert) (err error) {
+	ctx, span := startSpan(ctx, "UpdateAlert")
+	defer tracing.End(span, &err)
+
+	query := `UPDATE alerts SET title = $2, quote = $3, condition = $4, threshold = $5, cooldown_seconds = $6,
+		webhook_url = $7, secret = $8, last_price = $9, last_fired_at = $10 WHERE id = $1;`
+
+	tag, err := s.db.Exec(ctx, query, alert.ID, alert.Title, alert.Quote, string(alert.Condition), alert.Threshold,
+		int64(alert.Cooldown/time.Second), alert.WebhookURL, alert.Secret, alert.LastPrice, nullTime(alert.LastFiredAt))
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to update alert: %d", alert.ID))
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to update alert: %d", alert.ID))
+	}
+	return nil
+}
+
+// DeleteAlert deletes the alert together with its delivery log.
+func (s *Storage) DeleteAlert(ctx context.Context, id int64) (err error) {
+	ctx, span := startSpan(ctx, "DeleteAlert")
+	defer tracing.End(span, &err)
+
+	tag, err := s.db.Exec(ctx, `DELETE FROM alerts WHERE id = $1;`, id)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to delete alert: %d", id))
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to delete alert: %d", id))
+	}
+	return nil
+}
+
+func (s *Storage) SetAlertState(ctx context.Context, id int64, lastPrice decimal.Decimal, lastFiredAt time.Time) (err error) {
+	ctx, span := startSpan(ctx, "SetAlertState")
+	defer tracing.End(span, &err)
+
+	query := `UPDATE alerts SET last_price = $2, last_fired_at = $3 WHERE id = $1;`
+
+	if _, err := s.db.Exec(ctx, query, id, lastPrice, nullTime(lastFiredAt)); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to update alert: %d", id))
+	}
+	return nil
+}
+
+// StoreDelivery inserts delivery and sets its ID.
+func (s *Storage) StoreDelivery(ctx context.Context, delivery *entities.Delivery) (err error) {
+	ctx, span := startSpan(ctx, "StoreDelivery")
+	defer tracing.End(span, &err)
+
+	query := `INSERT INTO alert_deliveries (alert_id, attempt, status_code, error, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id;`
+
+	err = s.db.QueryRow(ctx, query, delivery.AlertID, delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.CreateTime).
+		Scan(&delivery.ID)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Delivery was not added")
+	}
+	return nil
+}
+
+// GetDeliveries returns up to limit deliveries of the alert, newest first.
+func (s *Storage) GetDeliveries(ctx context.Context, alertID int64, limit int) (_ []entities.Delivery, err error) {
+	ctx, span := startSpan(ctx, "GetDeliveries")
+	defer tracing.End(span, &err)
+
+	query := `SELECT id, alert_id, attempt, status_code, error, created_at FROM alert_deliveries
+		WHERE alert_id = $1 ORDER BY id DESC LIMIT $2;`
+
+	rows, err := s.db.Query(ctx, query, alertID, limit)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get deliveries: %d", alertID))
+	}
+	defer rows.Close()
+
+	var deliveries []entities.Delivery
+	for rows.Next() {
+		var d entities.Delivery
+		err := rows.Scan(&d.ID, &d.AlertID, &d.Attempt, &d.StatusCode, &d.Error, &d.CreateTime)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get deliveries: %d", alertID))
+		}
+		deliveries = append(deliveries, d)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get deliveries: %d", alertID))
+	}
+
+	return deliveries, nil
+}
+
+func scanAlert(row pgx.Row) (*entities.Alert, error) {
+	var (
+		alert     entities.Alert
+		condition string
+		cooldown  int64
+		firedAt   *time.Time
+	)
+	err := row.Scan(&alert.ID, &alert.Title, &alert.Quote, &condition, &alert.Threshold, &cooldown,
+		&alert.WebhookURL, &alert.Secret, &alert.LastPrice, &firedAt, &alert.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+	alert.Condition = entities.AlertCondition(condition)
+	alert.Cooldown = time.Duration(cooldown) * time.Second
+	if firedAt != nil {
+		alert.LastFiredAt = *firedAt
+	}
+	return &alert, nil
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"currency/internal/entities"
+	"currency/internal/tracing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+)
+
+// Lock is a session advisory lock. It is held on a dedicated connection
+// named after the instance, so Postgres releases it when the instance dies
+// and Holder can tell which instance holds it.
+type Lock struct {
+	s        *Storage
+	id       int64
+	instance string
+
+	mu   sync.Mutex
+	conn *pgx.Conn
+}
+
+func (s *Storage) NewLock(id int64, instance string) (*Lock, error) {
+	if instance == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "instance is empty")
+	}
+	return &Lock{s: s, id: id, instance: instance}, nil
+}
+
+// TryLock takes the lock, or checks the connection holding it is alive.
+func (l *Lock) TryLock(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err != nil {
+			l.close()
+			return false, errors.Wrap(entities.ErrInternalServer, "Lost leader lock connection")
+		}
+		return true, nil
+	}
+
+	config := l.s.db.Config().ConnConfig.Copy()
+	config.RuntimeParams["application_name"] = l.instance
+	conn, err := pgx.ConnectConfig(ctx, config)
+	if err != nil {
+		return false, errors.Wrap(entities.ErrInternalServer, "Unable to connect for leader lock")
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1);`, l.id).Scan(&locked); err != nil {
+		_ = conn.Close(ctx)
+		return false, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to take lock %d", l.id))
+	}
+	if !locked {
+		_ = conn.Close(ctx)
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Unlock releases the lock by closing its connection.
+func (l *Lock) Unlock(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	if err := l.conn.Close(ctx); err != nil {
+		l.conn = nil
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to release lock %d", l.id))
+	}
+	l.conn = nil
+	return nil
+}
+
+func (l *Lock) close() {
+	_ = l.conn.Close(context.Background())
+	l.conn = nil
+}
+
+// Holder returns the application_name of the session holding the lock.
+func (l *Lock) Holder(ctx context.Context) (_ string, err error) {
+	ctx, span := startSpan(ctx, "Holder")
+	defer tracing.End(span, &err)
+
+	// bigint ключ advisory lock хранится в pg_locks как classid (старшие 32 бита) и objid (младшие).
+	query := `SELECT a.application_name FROM pg_locks l JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype = 'advisory' AND l.granted AND l.objsubid = 1
+			AND l.classid = ($1::bigint >> 32)::int::oid AND l.objid = ($1::bigint & 4294967295)::oid;`
+
+	var holder string
+	err = l.s.db.QueryRow(ctx, query, l.id).Scan(&holder)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get holder of lock %d", l.id))
+	}
+	return holder, nil
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"currency/deployment/migrations"
+	"currency/internal/entities"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// migrationLockID serializes the migrators of the replicas starting at once.
+const migrationLockID int64 = 7300
+
+// Migrator applies Migrations and records them in schema_migrations.
+type Migrator struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	migrations []migrations.Migration
+}
+
+// NewMigrator loads the migrations in dir of fsys.
+func (s *Storage) NewMigrator(fsys fs.FS, dir string, logger *slog.Logger) (*Migrator, error) {
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	loaded, err := migrations.Load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: s.db, logger: logger, migrations: loaded}, nil
+}
+
+// locked runs f on a connection holding the migration lock.
+func (m *Migrator) locked(ctx context.Context, f func(conn *pgxpool.Conn) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Unable to acquire connection for migrations")
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1);`, migrationLockID); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Unable to take migration lock")
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1);`, migrationLockID); err != nil {
+			m.logger.Error("release migration lock failed", slog.Any("error", err))
+		}
+	}()
+
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);`
+	if _, err := conn.Exec(ctx, query); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Unable to create schema_migrations")
+	}
+	return f(conn)
+}
+
+func applied(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get applied migrations")
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get applied migrations")
+		}
+		versions[version] = appliedAt
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get applied migrations")
+	}
+	return versions, nil
+}
+
+// run executes sql and records the change of version in one transaction.
+func run(ctx context.Context, conn *pgxpool.Conn, sql, record string, args ...interface{}) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, migrations.StripTransaction(sql)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Up applies the pending migrations in order and returns how many it
+// applied. Migrations are safe to run from several instances at once.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	var count int
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		done, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			err := run(ctx, conn, migration.Up,
+				`INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`, migration.Version, migration.Name)
+			if err != nil {
+				return errors.Wrap(entities.ErrInternalServer,
+					fmt.Sprintf("Migration %d_%s failed: %s", migration.Version, migration.Name, err))
+			}
+			m.logger.InfoContext(ctx, "migration applied", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down rolls back the last steps applied migrations and returns how many it
+// rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("steps: %d", steps))
+	}
+
+	var count int
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		done, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			err := run(ctx, conn, migration.Down, `DELETE FROM schema_migrations WHERE version = $1;`, migration.Version)
+			if err != nil {
+				return errors.Wrap(entities.ErrInternalServer,
+					fmt.Sprintf("Rollback of %d_%s failed: %s", migration.Version, migration.Name, err))
+			}
+			m.logger.InfoContext(ctx, "migration rolled back", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status lists every migration with the time it was applied at.
+func (m *Migrator) Status(ctx context.Context) ([]migrations.Status, error) {
+	var statuses []migrations.Status
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		done, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			statuses = append(statuses, migrations.Status{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: done[migration.Version],
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
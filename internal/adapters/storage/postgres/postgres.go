@@ -3,11 +3,19 @@ package postgres
 import (
 	"context"
 	"fmt"
-	"github.com/jackc/pgx/v4"
+	"log/slog"
+	"strings"
+	"time"
 
 	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/metrics"
+	"currency/internal/tracing"
 	"currency/internal/usecases"
 
+	"github.com/jackc/pgtype"
+	shopspring "github.com/jackc/pgtype/ext/shopspring-numeric"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/pkg/errors"
 )
@@ -16,9 +24,17 @@ type Storage struct {
 	db *pgxpool.Pool
 }
 
-func NewStorage(ctx context.Context, connStr string) (*Storage, error) {
-	fmt.Printf("Connecting to %s\n", connStr)
-	pool, err := pgxpool.Connect(ctx, connStr)
+func NewStorage(ctx context.Context, connStr string, logger *slog.Logger) (*Storage, error) {
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	logger.InfoContext(ctx, "connecting to database", slog.String("conn", logging.Redact(connStr)))
+	config, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "Unable to parse connection string")
+	}
+	config.AfterConnect = registerNumeric
+	pool, err := pgxpool.ConnectConfig(ctx, config)
 	if err != nil {
 		return nil, errors.Wrap(err, "Unable to connect to database")
 	}
@@ -28,71 +44,393 @@ func NewStorage(ctx context.Context, connStr string) (*Storage, error) {
 	return &Storage{db: pool}, nil
 }
 
-func (s *Storage) Store(ctx context.Context, coins []entities.Coin) error {
-	query := `INSERT INTO coins (title, price, created_at) VALUES ($1, $2, $3);`
-	for _, coin := range coins {
-		_, err := s.db.Exec(ctx, query, coin.Title, coin.Price, coin.CreateTime)
+// registerNumeric makes conn read and write NUMERIC as decimal.Decimal, so
+// prices are never rounded to a float.
+func registerNumeric(_ context.Context, conn *pgx.Conn) error {
+	conn.ConnInfo().RegisterDataType(pgtype.DataType{Value: &shopspring.Numeric{}, Name: "numeric", OID: pgtype.NumericOID})
+	return nil
+}
+
+// Store inserts coins and moves latest_prices to the newest of them in one
+// transaction, and sets their ID to the id of the stored row; either every
+// coin is stored or none is. Stores are serialized with storeLockID.
+func (s *Storage) Store(ctx context.Context, coins []entities.Coin) (err error) {
+	ctx, span := startSpan(ctx, "Store")
+	defer tracing.End(span, &err)
+
+	if len(coins) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Читатели GetAfter идут по id, поэтому id выдаются и коммитятся по порядку:
+	// блокировка держится до конца транзакции.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1);`, storeLockID); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+	// COPY не возвращает id, поэтому берём их из последовательности заранее.
+	ids, err := nextIDs(ctx, tx, len(coins))
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+	columns := []string{"id", "title", "quote", "price", "provider", "created_at"}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"coins"}, columns, pgx.CopyFromSlice(len(coins), func(i int) ([]interface{}, error) {
+		c := coins[i]
+		return []interface{}{ids[i], c.Title, c.Quote, c.Price, c.Provider, c.CreateTime}, nil
+	}))
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+	if _, err := tx.Exec(ctx, upsertLatest, ids); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Latest prices were not updated")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+
+	for i, coin := range coins {
+		coins[i].ID = ids[i]
+		metrics.CoinsStored.WithLabelValues(coin.Title).Inc()
+	}
+	return nil
+}
+
+// storeLockID serializes Store, so coins become visible in the order of
+// their ids and GetAfter never skips a coin committed late.
+const storeLockID int64 = 7302
+
+// upsertLatest moves latest_prices to the newest of the coins with ids $1.
+// A price older than the stored one, e.g. a late median, doesn't replace it.
+const upsertLatest = `INSERT INTO latest_prices (title, quote, price, provider, created_at, coin_id)
+	SELECT DISTINCT ON (title, quote) title, quote, price, provider, created_at, id FROM coins
+	WHERE id = ANY($1::bigint[])
+	ORDER BY title, quote, created_at DESC, id DESC
+	ON CONFLICT (title, quote) DO UPDATE SET price = EXCLUDED.price, provider = EXCLUDED.provider,
+		created_at = EXCLUDED.created_at, coin_id = EXCLUDED.coin_id
+	WHERE latest_prices.created_at <= EXCLUDED.created_at;`
+
+// nextIDs takes n ids of coins in ascending order.
+func nextIDs(ctx context.Context, tx pgx.Tx, n int) ([]int64, error) {
+	query := `SELECT nextval(pg_get_serial_sequence('coins', 'id')) AS id FROM generate_series(1, $1) ORDER BY id;`
+
+	rows, err := tx.Query(ctx, query, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, n)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) != n {
+		return nil, errors.Errorf("got %d ids of %d", len(ids), n)
+	}
+	return ids, nil
+}
+
+// GetAfter returns up to limit coins stored after the row with the given id,
+// oldest first. Empty titles match every title.
+func (s *Storage) GetAfter(ctx context.Context, id int64, titles []string, limit int, options ...usecases.Option) (_ []entities.Coin, err error) {
+	ctx, span := startSpan(ctx, "GetAfter")
+	defer tracing.End(span, &err)
+
+	opts := &usecases.Options{}
+	for _, option := range options {
+		option(opts)
+	}
+	query := `SELECT id, title, quote, price, provider, created_at FROM coins
+		WHERE id > $1
+			AND (cardinality($2::varchar[]) = 0 OR title = ANY($2))
+			AND (cardinality($3::varchar[]) = 0 OR quote = ANY($3))
+		ORDER BY id LIMIT $4;`
+
+	if titles == nil {
+		titles = []string{}
+	}
+	quotes := opts.Quotes
+	if quotes == nil {
+		quotes = []string{}
+	}
+
+	rows, err := s.db.Query(ctx, query, id, titles, quotes, limit)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins after %d", id))
+	}
+	defer rows.Close()
+
+	var coins []entities.Coin
+	for rows.Next() {
+		var coin entities.Coin
+		err := rows.Scan(&coin.ID, &coin.Title, &coin.Quote, &coin.Price, &coin.Provider, &coin.CreateTime)
 		if err != nil {
-			return errors.Wrap(entities.ErrInternalServer, "Coin was not added")
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins after %d", id))
 		}
+		coins = append(coins, coin)
 	}
-	return nil
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins after %d", id))
+	}
+
+	return coins, nil
+}
+
+// LastID returns the id of the latest stored coin, 0 if none is.
+func (s *Storage) LastID(ctx context.Context) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "LastID")
+	defer tracing.End(span, &err)
+
+	var id int64
+	if err := s.db.QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM coins;`).Scan(&id); err != nil {
+		return 0, errors.Wrap(entities.ErrInternalServer, "Unable to get the last coin id")
+	}
+	return id, nil
 }
 
-func (s *Storage) Get(ctx context.Context, titles []string, options ...usecases.Option) ([]entities.Coin, error) {
-	fmt.Println(titles)
+// filter is shared by every Get query: $1 is the titles, $2 the quotes
+// (empty matches every quote), $3 and $4 the optional created_at bounds.
+const filter = `WHERE title = ANY($1::varchar[])
+	AND (cardinality($2::varchar[]) = 0 OR quote = ANY($2))
+	AND ($3::timestamp IS NULL OR created_at >= $3)
+	AND ($4::timestamp IS NULL OR created_at <= $4)`
+
+// tiers unions the raw prices with the hourly and daily rollups of retention,
+// so aggregates and candles cover the prices it rolled up. A rollup matches
+// filter by the start of its bucket.
+const tiers = `(
+		SELECT title, quote, created_at, price AS first_price, price AS last_price, price AS min_price,
+			price AS max_price, price AS sum_price, 1::bigint AS count, created_at AS first_at, created_at AS last_at, provider
+		FROM coins
+		UNION ALL
+		SELECT title, quote, bucket, first_price, last_price, min_price, max_price, sum_price, count, first_at, last_at, ''
+		FROM prices_1h
+		UNION ALL
+		SELECT title, quote, bucket, first_price, last_price, min_price, max_price, sum_price, count, first_at, last_at, ''
+		FROM prices_1d
+	) AS tiers `
+
+// Get returns one coin per stored quote of every title, in the order of
+// titles, with a single query. An empty quotes option matches every quote.
+// The last prices without a range are read from latest_prices. A title
+// without any price in the quotes is reported as entities.ErrNotFound, one
+// without prices in the range is left out.
+func (s *Storage) Get(ctx context.Context, titles []string, options ...usecases.Option) (_ []entities.Coin, err error) {
+	ctx, span := startSpan(ctx, "Get")
+	defer tracing.End(span, &err)
+
+	if len(titles) == 0 {
+		return nil, nil
+	}
 	opts := &usecases.Options{}
 	for _, option := range options {
 		option(opts)
 	}
+	quotes := opts.Quotes
+	if quotes == nil {
+		quotes = []string{}
+	}
+	from, to := opts.Range(time.Now())
+
 	var query string
-	switch opts.FuncType {
-	case usecases.Max:
-		query = `SELECT title, MAX(price), created_at FROM coins WHERE title = $1 GROUP BY title;`
-	case usecases.Min:
-		query = `SELECT title, MIN(price), created_at FROM coins WHERE title = $1 GROUP BY title;`
-	case usecases.Avg:
-		query = `SELECT title, AVG(price), created_at FROM coins WHERE title = $1 GROUP BY title;`
+	switch {
+	case opts.FuncType == usecases.Max:
+		query = `SELECT title, quote, MAX(max_price), MAX(last_at), MIN(first_at), '' FROM ` + tiers + filter +
+			` GROUP BY title, quote ORDER BY title, quote;`
+	case opts.FuncType == usecases.Min:
+		query = `SELECT title, quote, MIN(min_price), MAX(last_at), MIN(first_at), '' FROM ` + tiers + filter +
+			` GROUP BY title, quote ORDER BY title, quote;`
+	case opts.FuncType == usecases.Avg:
+		// среднее по сумме и количеству точно и для сырых цен, и для агрегатов.
+		query = `SELECT title, quote, SUM(sum_price) / SUM(count), MAX(last_at), MIN(first_at), '' FROM ` + tiers + filter +
+			` GROUP BY title, quote ORDER BY title, quote;`
+	case from.IsZero() && to.IsZero():
+		// последняя цена без границ уже лежит в latest_prices.
+		query = `SELECT title, quote, price, created_at, NULL::timestamp, provider FROM latest_prices ` + filter +
+			` ORDER BY title, quote;`
 	default:
-		query = `SELECT title, price, created_at FROM coins WHERE title = $1 ORDER BY created_at DESC LIMIT 1;`
+		query = `SELECT DISTINCT ON (title, quote) title, quote, last_price, last_at, NULL::timestamp, provider FROM ` + tiers + filter +
+			` ORDER BY title, quote, last_at DESC;`
 	}
 
-	var coin entities.Coin
-	var coins []entities.Coin
-	for _, t := range titles {
-		err := s.db.QueryRow(ctx, query, t).Scan(&coin.Title, &coin.Price, &coin.CreateTime)
+	rows, err := s.db.Query(ctx, query, titles, quotes, nullTime(from), nullTime(to))
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+	defer rows.Close()
+
+	byTitle := make(map[string][]entities.Coin, len(titles))
+	for rows.Next() {
+		var coin entities.Coin
+		var from *time.Time
+		err := rows.Scan(&coin.Title, &coin.Quote, &coin.Price, &coin.CreateTime, &from, &coin.Provider)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+		}
+		if from != nil {
+			coin.From = *from
+		}
+		byTitle[coin.Title] = append(byTitle[coin.Title], coin)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+
+	if len(byTitle) < len(titles) {
+		stored, err := s.stored(ctx, titles, quotes)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("Unable to get coin: %s", coin.Title))
+			return nil, err
+		}
+		for _, t := range titles {
+			if len(byTitle[t]) == 0 && !stored[t] {
+				return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to get coin: %s", t))
 			}
-			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coin: %s", coin.Title))
 		}
-		coins = append(coins, coin)
+	}
+
+	var coins []entities.Coin
+	for _, t := range titles {
+		coins = append(coins, byTitle[t]...)
 	}
 
 	return coins, nil
 }
 
-func (s *Storage) GetTitles(ctx context.Context) ([]string, error) {
-	var titles []string
-	query := `SELECT DISTINCT title FROM coins;`
+// stored reports which of titles have a price in any of quotes.
+func (s *Storage) stored(ctx context.Context, titles, quotes []string) (map[string]bool, error) {
+	query := `SELECT DISTINCT title FROM latest_prices ` + filter + `;`
 
-	rows, err := s.db.Query(ctx, query)
+	rows, err := s.db.Query(ctx, query, titles, quotes, nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "Unable to get titles")
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
 	}
-
 	defer rows.Close()
 
+	stored := make(map[string]bool, len(titles))
 	for rows.Next() {
 		var title string
-		err := rows.Scan(&title)
+		if err := rows.Scan(&title); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+		}
+		stored[title] = true
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+	return stored, nil
+}
+
+// GetCandles buckets prices with date_bin and returns one OHLC candle per
+// bucket, title and quote, ordered by title as in titles, quote and bucket
+// start. Buckets without prices are skipped, so the result may be empty.
+func (s *Storage) GetCandles(ctx context.Context, titles []string, interval time.Duration, options ...usecases.Option) (_ []entities.Candle, err error) {
+	ctx, span := startSpan(ctx, "GetCandles")
+	defer tracing.End(span, &err)
+
+	if len(titles) == 0 {
+		return nil, nil
+	}
+	opts := &usecases.Options{}
+	for _, option := range options {
+		option(opts)
+	}
+	query := `SELECT title, quote, bucket,
+			(array_agg(first_price ORDER BY first_at))[1],
+			MAX(max_price),
+			MIN(min_price),
+			(array_agg(last_price ORDER BY last_at DESC))[1]
+		FROM (
+			SELECT title, quote, first_price, last_price, min_price, max_price, first_at, last_at,
+				date_bin($5::interval, created_at, TIMESTAMP '2000-01-01') AS bucket
+			FROM ` + tiers + filter + `
+		) AS c
+		GROUP BY title, quote, bucket ORDER BY array_position($1::varchar[], title), quote, bucket;`
+
+	quotes := opts.Quotes
+	if quotes == nil {
+		quotes = []string{}
+	}
+	from, to := opts.Range(time.Now())
+
+	rows, err := s.db.Query(ctx, query, titles, quotes, nullTime(from), nullTime(to), interval)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get candles: %s", strings.Join(titles, ",")))
+	}
+	defer rows.Close()
+
+	var candles []entities.Candle
+	for rows.Next() {
+		candle := entities.Candle{Interval: interval}
+		err := rows.Scan(&candle.Title, &candle.Quote, &candle.Start, &candle.Open, &candle.High, &candle.Low, &candle.Close)
 		if err != nil {
-			return nil, errors.Wrap(err, fmt.Sprintf("Unable to get title: %s", title))
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get candles: %s", strings.Join(titles, ",")))
 		}
+		candles = append(candles, candle)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get candles: %s", strings.Join(titles, ",")))
+	}
+
+	return candles, nil
+}
 
-		titles = append(titles, title)
+// nullTime maps an open bound to NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
 	}
+	return &t
+}
+
+func (s *Storage) GetUpdates(ctx context.Context) (_ []entities.SymbolStatus, err error) {
+	ctx, span := startSpan(ctx, "GetUpdates")
+	defer tracing.End(span, &err)
+
+	query := `SELECT title, quote, created_at FROM latest_prices ORDER BY title, quote;`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates")
+	}
+	defer rows.Close()
+
+	var updates []entities.SymbolStatus
+	for rows.Next() {
+		var update entities.SymbolStatus
+		if err := rows.Scan(&update.Title, &update.Quote, &update.UpdateTime); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates")
+		}
+		updates = append(updates, update)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates")
+	}
+
+	return updates, nil
+}
+
+func (s *Storage) Ping(ctx context.Context) (err error) {
+	ctx, span := startSpan(ctx, "Ping")
+	defer tracing.End(span, &err)
+
+	if err := s.db.Ping(ctx); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Could not connect to pool")
+	}
+	return nil
+}
 
-	return titles, nil
+// Close waits for the acquired connections to be released and closes the pool.
+func (s *Storage) Close() {
+	s.db.Close()
 }
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/tracing"
+
+	"github.com/pkg/errors"
+)
+
+const rollupColumns = `title, quote, bucket, first_price, last_price, min_price, max_price, sum_price, count, first_at, last_at`
+
+// mergeRollup merges a bucket into the rollup of the same bucket stored by an
+// earlier run, if any; %[1]s is the rollup table.
+const mergeRollup = `ON CONFLICT (title, quote, bucket) DO UPDATE SET
+	first_price = CASE WHEN EXCLUDED.first_at < %[1]s.first_at THEN EXCLUDED.first_price ELSE %[1]s.first_price END,
+	last_price = CASE WHEN EXCLUDED.last_at >= %[1]s.last_at THEN EXCLUDED.last_price ELSE %[1]s.last_price END,
+	min_price = LEAST(%[1]s.min_price, EXCLUDED.min_price),
+	max_price = GREATEST(%[1]s.max_price, EXCLUDED.max_price),
+	sum_price = %[1]s.sum_price + EXCLUDED.sum_price,
+	count = %[1]s.count + EXCLUDED.count,
+	first_at = LEAST(%[1]s.first_at, EXCLUDED.first_at),
+	last_at = GREATEST(%[1]s.last_at, EXCLUDED.last_at)`
+
+// RollUpRaw moves the coins created before the hour of before into
+// prices_1h in one statement and returns how many it moved.
+func (s *Storage) RollUpRaw(ctx context.Context, before time.Time) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "RollUpRaw")
+	defer tracing.End(span, &err)
+
+	query := `WITH moved AS (
+			DELETE FROM coins WHERE created_at < date_trunc('hour', $1::timestamp)
+			RETURNING id, title, quote, price, created_at
+		), rolled AS (
+			INSERT INTO prices_1h (` + rollupColumns + `)
+			SELECT title, quote, date_trunc('hour', created_at),
+				(array_agg(price ORDER BY created_at, id))[1],
+				(array_agg(price ORDER BY created_at DESC, id DESC))[1],
+				MIN(price), MAX(price), SUM(price), COUNT(*), MIN(created_at), MAX(created_at)
+			FROM moved GROUP BY title, quote, date_trunc('hour', created_at)
+			` + fmt.Sprintf(mergeRollup, "prices_1h") + `
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM moved;`
+
+	var moved int64
+	if err := s.db.QueryRow(ctx, query, before).Scan(&moved); err != nil {
+		return 0, errors.Wrap(entities.ErrInternalServer, "Unable to roll up raw prices")
+	}
+	return moved, nil
+}
+
+// RollUpHourly moves the prices_1h buckets before the day of before into
+// prices_1d in one statement and returns how many it moved.
+func (s *Storage) RollUpHourly(ctx context.Context, before time.Time) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "RollUpHourly")
+	defer tracing.End(span, &err)
+
+	query := `WITH moved AS (
+			DELETE FROM prices_1h WHERE bucket < date_trunc('day', $1::timestamp)
+			RETURNING ` + rollupColumns + `
+		), rolled AS (
+			INSERT INTO prices_1d (` + rollupColumns + `)
+			SELECT title, quote, date_trunc('day', bucket),
+				(array_agg(first_price ORDER BY first_at))[1],
+				(array_agg(last_price ORDER BY last_at DESC))[1],
+				MIN(min_price), MAX(max_price), SUM(sum_price), SUM(count), MIN(first_at), MAX(last_at)
+			FROM moved GROUP BY title, quote, date_trunc('day', bucket)
+			` + fmt.Sprintf(mergeRollup, "prices_1d") + `
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM moved;`
+
+	var moved int64
+	if err := s.db.QueryRow(ctx, query, before).Scan(&moved); err != nil {
+		return 0, errors.Wrap(entities.ErrInternalServer, "Unable to roll up hourly prices")
+	}
+	return moved, nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"currency/internal/entities"
+	"currency/internal/tracing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+)
+
+// AddSymbol inserts symbol and sets its CreateTime.
+func (s *Storage) AddSymbol(ctx context.Context, symbol *entities.Symbol) (err error) {
+	ctx, span := startSpan(ctx, "AddSymbol")
+	defer tracing.End(span, &err)
+
+	query := `INSERT INTO tracked_symbols (title, paused) VALUES ($1, $2)
+		ON CONFLICT (title) DO NOTHING RETURNING created_at;`
+
+	err = s.db.QueryRow(ctx, query, symbol.Title, symbol.Paused).Scan(&symbol.CreateTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(entities.ErrConflict, fmt.Sprintf("Symbol is already tracked: %s", symbol.Title))
+	}
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Symbol was not added: %s", symbol.Title))
+	}
+	return nil
+}
+
+func (s *Storage) GetSymbols(ctx context.Context) (_ []entities.Symbol, err error) {
+	ctx, span := startSpan(ctx, "GetSymbols")
+	defer tracing.End(span, &err)
+
+	query := `SELECT title, paused, created_at FROM tracked_symbols ORDER BY title;`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols")
+	}
+	defer rows.Close()
+
+	var symbols []entities.Symbol
+	for rows.Next() {
+		var symbol entities.Symbol
+		if err := rows.Scan(&symbol.Title, &symbol.Paused, &symbol.CreateTime); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols")
+		}
+		symbols = append(symbols, symbol)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols")
+	}
+
+	return symbols, nil
+}
+
+func (s *Storage) SetSymbolPaused(ctx context.Context, title string, paused bool) (_ *entities.Symbol, err error) {
+	ctx, span := startSpan(ctx, "SetSymbolPaused")
+	defer tracing.End(span, &err)
+
+	query := `UPDATE tracked_symbols SET paused = $2 WHERE title = $1 RETURNING title, paused, created_at;`
+
+	var symbol entities.Symbol
+	err = s.db.QueryRow(ctx, query, title, paused).Scan(&symbol.Title, &symbol.Paused, &symbol.CreateTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to update symbol: %s", title))
+	}
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to update symbol: %s", title))
+	}
+	return &symbol, nil
+}
+
+func (s *Storage) DeleteSymbol(ctx context.Context, title string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteSymbol")
+	defer tracing.End(span, &err)
+
+	tag, err := s.db.Exec(ctx, `DELETE FROM tracked_symbols WHERE title = $1;`, title)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to delete symbol: %s", title))
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to delete symbol: %s", title))
+	}
+	return nil
+}
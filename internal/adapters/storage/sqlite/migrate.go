@@ -0,0 +1,156 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"currency/deployment/migrations"
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+// Migrator applies migrations and records them in schema_migrations. The
+// storage has a single connection, so migrators never run at once.
+type Migrator struct {
+	db         *sql.DB
+	logger     *slog.Logger
+	migrations []migrations.Migration
+}
+
+// NewMigrator loads the migrations in dir of fsys.
+func (s *Storage) NewMigrator(fsys fs.FS, dir string, logger *slog.Logger) (*Migrator, error) {
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	loaded, err := migrations.Load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: s.db, logger: logger, migrations: loaded}, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	);`
+	if _, err := m.db.ExecContext(ctx, query); err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to create schema_migrations")
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get applied migrations")
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get applied migrations")
+		}
+		if versions[version], err = parseTime(appliedAt); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get applied migrations")
+		}
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get applied migrations")
+	}
+	return versions, nil
+}
+
+// run executes sql and records the change of version in one transaction.
+func (m *Migrator) run(ctx context.Context, sql, record string, args ...interface{}) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, migrations.StripTransaction(sql)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Up applies the pending migrations in order and returns how many it
+// applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, migration := range m.migrations {
+		if _, ok := done[migration.Version]; ok {
+			continue
+		}
+		err := m.run(ctx, migration.Up, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?);`,
+			migration.Version, migration.Name, formatTime(time.Now()))
+		if err != nil {
+			return count, errors.Wrap(entities.ErrInternalServer,
+				fmt.Sprintf("Migration %d_%s failed: %s", migration.Version, migration.Name, err))
+		}
+		m.logger.InfoContext(ctx, "migration applied", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+		count++
+	}
+	return count, nil
+}
+
+// Down rolls back the last steps applied migrations and returns how many it
+// rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("steps: %d", steps))
+	}
+	done, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := done[migration.Version]; !ok {
+			continue
+		}
+		err := m.run(ctx, migration.Down, `DELETE FROM schema_migrations WHERE version = ?;`, migration.Version)
+		if err != nil {
+			return count, errors.Wrap(entities.ErrInternalServer,
+				fmt.Sprintf("Rollback of %d_%s failed: %s", migration.Version, migration.Name, err))
+		}
+		m.logger.InfoContext(ctx, "migration rolled back", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+		count++
+	}
+	return count, nil
+}
+
+// Status lists every migration with the time it was applied at.
+func (m *Migrator) Status(ctx context.Context) ([]migrations.Status, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []migrations.Status
+	for _, migration := range m.migrations {
+		statuses = append(statuses, migrations.Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: done[migration.Version],
+		})
+	}
+	return statuses, nil
+}
@@ -0,0 +1,505 @@
+// Package sqlite stores prices, symbols and alerts in a SQLite file for the
+// deployments that can't run Postgres. Retention and leader election are
+// not supported: a single instance owns the file.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/metrics"
+	"currency/internal/tracing"
+	"currency/internal/usecases"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// timeFormat is fixed width, so the UTC times stored as text compare in order.
+const timeFormat = "2006-01-02 15:04:05.000000"
+
+type Storage struct {
+	db *sql.DB
+}
+
+// NewStorage opens the database file at path, creating it if needed.
+func NewStorage(ctx context.Context, path string, logger *slog.Logger) (*Storage, error) {
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	if path == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "path is empty")
+	}
+	logger.InfoContext(ctx, "opening database", slog.String("path", path))
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate")
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "Unable to open database")
+	}
+	// SQLite пишет в файл по одному, поэтому одного соединения достаточно и
+	// транзакции не получают SQLITE_BUSY друг от друга.
+	db.SetMaxOpenConns(1)
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(entities.ErrInternalServer, "Could not open database")
+	}
+	return &Storage{db: db}, nil
+}
+
+// Store inserts coins and moves latest_prices to the newest of them in one
+// transaction, and sets their ID to the id of the stored row; either every
+// coin is stored or none is.
+func (s *Storage) Store(ctx context.Context, coins []entities.Coin) (err error) {
+	ctx, span := startSpan(ctx, "Store")
+	defer tracing.End(span, &err)
+
+	if len(coins) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	insert, err := tx.PrepareContext(ctx, `INSERT INTO coins (title, quote, price, price_sort, provider, created_at) VALUES (?, ?, ?, ?, ?, ?);`)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+	defer insert.Close()
+	upsert, err := tx.PrepareContext(ctx, upsertLatest)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Latest prices were not updated")
+	}
+	defer upsert.Close()
+
+	ids := make([]int64, len(coins))
+	for i, c := range coins {
+		created := formatTime(c.CreateTime)
+		res, err := insert.ExecContext(ctx, c.Title, c.Quote, c.Price, c.Price.InexactFloat64(), c.Provider, created)
+		if err != nil {
+			return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+		}
+		if ids[i], err = res.LastInsertId(); err != nil {
+			return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+		}
+		if _, err := upsert.ExecContext(ctx, c.Title, c.Quote, c.Price, c.Provider, created, ids[i]); err != nil {
+			return errors.Wrap(entities.ErrInternalServer, "Latest prices were not updated")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Coins were not added")
+	}
+
+	for i, coin := range coins {
+		coins[i].ID = ids[i]
+		metrics.CoinsStored.WithLabelValues(coin.Title).Inc()
+	}
+	return nil
+}
+
+// upsertLatest moves latest_prices of a pair to a stored coin. A price older
+// than the stored one, e.g. a late median, doesn't replace it.
+const upsertLatest = `INSERT INTO latest_prices (title, quote, price, provider, created_at, coin_id)
+	VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT (title, quote) DO UPDATE SET price = excluded.price, provider = excluded.provider,
+		created_at = excluded.created_at, coin_id = excluded.coin_id
+	WHERE latest_prices.created_at <= excluded.created_at;`
+
+// GetAfter returns up to limit coins stored after the row with the given id,
+// oldest first. Empty titles match every title.
+func (s *Storage) GetAfter(ctx context.Context, id int64, titles []string, limit int, options ...usecases.Option) (_ []entities.Coin, err error) {
+	ctx, span := startSpan(ctx, "GetAfter")
+	defer tracing.End(span, &err)
+
+	opts := newOptions(options)
+	query := `SELECT id, title, quote, price, provider, created_at FROM coins
+		WHERE id > ?1
+			AND (json_array_length(?2) = 0 OR title IN (SELECT value FROM json_each(?2)))
+			AND (json_array_length(?3) = 0 OR quote IN (SELECT value FROM json_each(?3)))
+		ORDER BY id LIMIT ?4;`
+
+	rows, err := s.db.QueryContext(ctx, query, id, jsonList(titles), jsonList(opts.Quotes), limit)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins after %d", id))
+	}
+	defer rows.Close()
+
+	var coins []entities.Coin
+	for rows.Next() {
+		var coin entities.Coin
+		var created string
+		if err := rows.Scan(&coin.ID, &coin.Title, &coin.Quote, &coin.Price, &coin.Provider, &created); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins after %d", id))
+		}
+		if coin.CreateTime, err = parseTime(created); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins after %d", id))
+		}
+		coins = append(coins, coin)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins after %d", id))
+	}
+
+	return coins, nil
+}
+
+// LastID returns the id of the latest stored coin, 0 if none is.
+func (s *Storage) LastID(ctx context.Context) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "LastID")
+	defer tracing.End(span, &err)
+
+	var id int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM coins;`).Scan(&id); err != nil {
+		return 0, errors.Wrap(entities.ErrInternalServer, "Unable to get the last coin id")
+	}
+	return id, nil
+}
+
+// filter is shared by every Get query: ?1 is the titles and ?2 the quotes
+// as JSON arrays (no quotes match every quote), ?3 and ?4 the optional
+// created_at bounds.
+const filter = `WHERE title IN (SELECT value FROM json_each(?1))
+	AND (json_array_length(?2) = 0 OR quote IN (SELECT value FROM json_each(?2)))
+	AND (?3 IS NULL OR created_at >= ?3)
+	AND (?4 IS NULL OR created_at <= ?4)`
+
+// Get returns one coin per stored quote of every title, in the order of
+// titles. The last prices without a range are read from latest_prices. A
+// title without any price in the quotes is reported as entities.ErrNotFound,
+// one without prices in the range is left out. SQLite has no exact decimal
+// arithmetic: MIN and MAX order by price_sort and return the exact price of
+// the row they pick, AVG is folded in Go from the exact prices.
+func (s *Storage) Get(ctx context.Context, titles []string, options ...usecases.Option) (_ []entities.Coin, err error) {
+	ctx, span := startSpan(ctx, "Get")
+	defer tracing.End(span, &err)
+
+	if len(titles) == 0 {
+		return nil, nil
+	}
+	opts := newOptions(options)
+	from, to := opts.Range(time.Now())
+
+	if opts.FuncType == usecases.Avg {
+		return s.average(ctx, titles, opts, from, to)
+	}
+
+	var query string
+	switch {
+	case opts.FuncType == usecases.Max:
+		query = pick("price_sort DESC, created_at, id", "first_at", "''")
+	case opts.FuncType == usecases.Min:
+		query = pick("price_sort, created_at, id", "first_at", "''")
+	case from.IsZero() && to.IsZero():
+		query = `SELECT title, quote, price, created_at, NULL, provider FROM latest_prices ` + filter + ` ORDER BY title, quote;`
+	default:
+		query = pick("created_at DESC, id DESC", "NULL", "provider")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, jsonList(titles), jsonList(opts.Quotes), nullTime(from), nullTime(to))
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+	defer rows.Close()
+
+	byTitle := make(map[string][]entities.Coin, len(titles))
+	for rows.Next() {
+		coin, err := scanCoin(rows)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+		}
+		byTitle[coin.Title] = append(byTitle[coin.Title], *coin)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+
+	return s.inOrder(ctx, titles, opts, byTitle)
+}
+
+// average folds the prices in the range read oldest first, so the average
+// is as exact as decimal division.
+func (s *Storage) average(ctx context.Context, titles []string, opts *usecases.Options, from, to time.Time) ([]entities.Coin, error) {
+	query := `SELECT title, quote, price, created_at, provider FROM coins ` + filter + ` ORDER BY title, quote, created_at, id;`
+
+	all, err := s.series(ctx, query, titles, opts, from, to, 0)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+
+	byTitle := make(map[string][]entities.Coin, len(titles))
+	for _, sr := range all {
+		byTitle[sr.last.Title] = append(byTitle[sr.last.Title], entities.Coin{
+			Title:      sr.last.Title,
+			Quote:      sr.last.Quote,
+			Price:      sr.sum.Div(decimal.NewFromInt(sr.count)),
+			CreateTime: sr.last.CreateTime,
+			From:       sr.first.CreateTime,
+		})
+	}
+	return s.inOrder(ctx, titles, opts, byTitle)
+}
+
+// inOrder returns the coins of byTitle in the order of titles, reporting the
+// titles without any stored price as entities.ErrNotFound.
+func (s *Storage) inOrder(ctx context.Context, titles []string, opts *usecases.Options, byTitle map[string][]entities.Coin) ([]entities.Coin, error) {
+	if len(byTitle) < len(titles) {
+		stored, err := s.stored(ctx, titles, opts.Quotes)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range titles {
+			if len(byTitle[t]) == 0 && !stored[t] {
+				return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to get coin: %s", t))
+			}
+		}
+	}
+
+	var coins []entities.Coin
+	for _, t := range titles {
+		coins = append(coins, byTitle[t]...)
+	}
+
+	return coins, nil
+}
+
+// pick selects the first price of every pair in the range in order, with
+// the time of the last price in the range and the from and provider columns
+// in the shape scanCoin reads.
+func pick(order, from, provider string) string {
+	return `SELECT title, quote, price, last_at, ` + from + `, ` + provider + ` FROM (
+			SELECT title, quote, price, provider,
+				ROW_NUMBER() OVER (PARTITION BY title, quote ORDER BY ` + order + `) AS n,
+				MAX(created_at) OVER (PARTITION BY title, quote) AS last_at,
+				MIN(created_at) OVER (PARTITION BY title, quote) AS first_at
+			FROM coins ` + filter + `
+		) WHERE n = 1 ORDER BY title, quote;`
+}
+
+func scanCoin(r row) (*entities.Coin, error) {
+	var coin entities.Coin
+	var created string
+	var from sql.NullString
+	if err := r.Scan(&coin.Title, &coin.Quote, &coin.Price, &created, &from, &coin.Provider); err != nil {
+		return nil, err
+	}
+	var err error
+	if coin.CreateTime, err = parseTime(created); err != nil {
+		return nil, err
+	}
+	if from.Valid {
+		if coin.From, err = parseTime(from.String); err != nil {
+			return nil, err
+		}
+	}
+	return &coin, nil
+}
+
+// stored reports which of titles have a price in any of quotes.
+func (s *Storage) stored(ctx context.Context, titles, quotes []string) (map[string]bool, error) {
+	query := `SELECT DISTINCT title FROM latest_prices ` + filter + `;`
+
+	rows, err := s.db.QueryContext(ctx, query, jsonList(titles), jsonList(quotes), nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+	defer rows.Close()
+
+	stored := make(map[string]bool, len(titles))
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+		}
+		stored[title] = true
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get coins: %s", strings.Join(titles, ",")))
+	}
+	return stored, nil
+}
+
+// GetCandles returns one OHLC candle per bucket, title and quote, ordered by
+// title as in titles, quote and bucket start. Buckets are aligned as in
+// postgres and buckets without prices are skipped.
+func (s *Storage) GetCandles(ctx context.Context, titles []string, interval time.Duration, options ...usecases.Option) (_ []entities.Candle, err error) {
+	ctx, span := startSpan(ctx, "GetCandles")
+	defer tracing.End(span, &err)
+
+	if len(titles) == 0 {
+		return nil, nil
+	}
+	if interval <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("interval: %s", interval))
+	}
+	opts := newOptions(options)
+	from, to := opts.Range(time.Now())
+	query := `SELECT title, quote, price, created_at, provider FROM coins ` + filter + ` ORDER BY title, quote, created_at, id;`
+
+	all, err := s.series(ctx, query, titles, opts, from, to, interval)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to get candles: %s", strings.Join(titles, ",")))
+	}
+
+	byTitle := make(map[string][]entities.Candle, len(titles))
+	for _, sr := range all {
+		byTitle[sr.last.Title] = append(byTitle[sr.last.Title], entities.Candle{
+			Title:    sr.last.Title,
+			Quote:    sr.last.Quote,
+			Start:    sr.bucket,
+			Interval: interval,
+			Open:     sr.first.Price,
+			High:     sr.max,
+			Low:      sr.min,
+			Close:    sr.last.Price,
+		})
+	}
+
+	var candles []entities.Candle
+	for _, t := range titles {
+		candles = append(candles, byTitle[t]...)
+	}
+	return candles, nil
+}
+
+// series folds the prices of one pair, or of one bucket of a pair, read
+// oldest first.
+type series struct {
+	bucket      time.Time
+	first, last entities.Coin
+	min, max    decimal.Decimal
+	sum         decimal.Decimal
+	count       int64
+}
+
+func (sr *series) add(coin entities.Coin) {
+	if sr.count == 0 {
+		sr.first, sr.min, sr.max = coin, coin.Price, coin.Price
+	}
+	sr.last = coin
+	sr.min = decimal.Min(sr.min, coin.Price)
+	sr.max = decimal.Max(sr.max, coin.Price)
+	sr.sum = sr.sum.Add(coin.Price)
+	sr.count++
+}
+
+// series runs query, which reads coins ordered by title, quote and time, and
+// folds them per pair, or per pair and bucket of interval if it isn't zero.
+func (s *Storage) series(ctx context.Context, query string, titles []string, opts *usecases.Options,
+	from, to time.Time, interval time.Duration,
+) ([]*series, error) {
+	rows, err := s.db.QueryContext(ctx, query, jsonList(titles), jsonList(opts.Quotes), nullTime(from), nullTime(to))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var all []*series
+	for rows.Next() {
+		var coin entities.Coin
+		var created string
+		if err := rows.Scan(&coin.Title, &coin.Quote, &coin.Price, &created, &coin.Provider); err != nil {
+			return nil, err
+		}
+		if coin.CreateTime, err = parseTime(created); err != nil {
+			return nil, err
+		}
+
+		var start time.Time
+		if interval > 0 {
+			start = usecases.CandleStart(coin.CreateTime, interval)
+		}
+		if n := len(all); n == 0 || all[n-1].last.Title != coin.Title || all[n-1].last.Quote != coin.Quote || !all[n-1].bucket.Equal(start) {
+			all = append(all, &series{bucket: start})
+		}
+		all[len(all)-1].add(coin)
+	}
+	return all, rows.Err()
+}
+
+func (s *Storage) GetUpdates(ctx context.Context) (_ []entities.SymbolStatus, err error) {
+	ctx, span := startSpan(ctx, "GetUpdates")
+	defer tracing.End(span, &err)
+
+	query := `SELECT title, quote, created_at FROM latest_prices ORDER BY title, quote;`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates")
+	}
+	defer rows.Close()
+
+	var updates []entities.SymbolStatus
+	for rows.Next() {
+		var update entities.SymbolStatus
+		var updated string
+		if err := rows.Scan(&update.Title, &update.Quote, &updated); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates")
+		}
+		if update.UpdateTime, err = parseTime(updated); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates")
+		}
+		updates = append(updates, update)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates")
+	}
+
+	return updates, nil
+}
+
+func (s *Storage) Ping(ctx context.Context) (err error) {
+	ctx, span := startSpan(ctx, "Ping")
+	defer tracing.End(span, &err)
+
+	if err := s.db.PingContext(ctx); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, "Could not open database")
+	}
+	return nil
+}
+
+// Close waits for the running queries and closes the database.
+func (s *Storage) Close() {
+	_ = s.db.Close()
+}
+
+func newOptions(options []usecases.Option) *usecases.Options {
+	opts := &usecases.Options{}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts
+}
+
+// jsonList encodes values for json_each; nil becomes an empty array.
+func jsonList(values []string) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+	b, _ := json.Marshal(values)
+	return string(b)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+func parseTime(s string) (time.Time, error) {
+	return time.ParseInLocation(timeFormat, s, time.UTC)
+}
+
+// nullTime maps an open bound to NULL.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return formatTime(t)
+}
@@ -0,0 +1,112 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"currency/deployment/migrations"
+	"currency/internal/adapters/storage/sqlite"
+	"currency/internal/adapters/storage/storagetest"
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStorage(t *testing.T) *sqlite.Storage {
+	t.Helper()
+	s, err := sqlite.NewStorage(context.Background(), filepath.Join(t.TempDir(), "currency.db"), logging.Nop())
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newMigrated returns a storage with every migration applied.
+func newMigrated(t *testing.T) *sqlite.Storage {
+	t.Helper()
+	s := newStorage(t)
+	migrator, err := s.NewMigrator(migrations.SQLite, "sqlite", logging.Nop())
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return s
+}
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		return newMigrated(t)
+	})
+}
+
+func TestStorage_Get_ExactAggregates(t *testing.T) {
+	ctx := context.Background()
+	s := newMigrated(t)
+
+	now := time.Now().UTC()
+	require.NoError(t, s.Store(ctx, []entities.Coin{
+		{Title: "BTC", Quote: "USD", Price: decimal.RequireFromString("103512.456"), CreateTime: now.Add(-2 * time.Minute)},
+		{Title: "BTC", Quote: "USD", Price: decimal.RequireFromString("0.000000123456789"), CreateTime: now.Add(-time.Minute)},
+		{Title: "ETH", Quote: "USD", Price: decimal.RequireFromString("0.1"), CreateTime: now.Add(-2 * time.Minute)},
+		{Title: "ETH", Quote: "USD", Price: decimal.RequireFromString("0.2"), CreateTime: now.Add(-time.Minute)},
+	}))
+
+	for want, option := range map[string]usecases.Option{
+		"103512.456":        usecases.WithMaxFunc(),
+		"0.000000123456789": usecases.WithMinFunc(),
+	} {
+		coins, err := s.Get(ctx, []string{"BTC"}, option)
+		require.NoError(t, err)
+		require.Len(t, coins, 1)
+		assert.Equal(t, want, coins[0].Price.String())
+	}
+
+	coins, err := s.Get(ctx, []string{"ETH"}, usecases.WithAvgFunc())
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	assert.Equal(t, "0.15", coins[0].Price.String())
+}
+
+func TestNewStorage(t *testing.T) {
+	_, err := sqlite.NewStorage(context.Background(), "", logging.Nop())
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+	_, err = sqlite.NewStorage(context.Background(), "currency.db", nil)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+	migrator, err := s.NewMigrator(migrations.SQLite, "sqlite", logging.Nop())
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, applied)
+	again, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, again)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, applied)
+	for _, status := range statuses {
+		assert.False(t, status.AppliedAt.IsZero())
+	}
+
+	rolled, err := migrator.Down(ctx, applied)
+	require.NoError(t, err)
+	assert.Equal(t, applied, rolled)
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.AppliedAt.IsZero())
+	}
+
+	_, err = migrator.Down(ctx, 0)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+}
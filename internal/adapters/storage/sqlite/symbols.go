@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/tracing"
+
+	"github.com/pkg/errors"
+)
+
+// AddSymbol inserts symbol and sets its CreateTime.
+func (s *Storage) AddSymbol(ctx context.Context, symbol *entities.Symbol) (err error) {
+	ctx, span := startSpan(ctx, "AddSymbol")
+	defer tracing.End(span, &err)
+
+	query := `INSERT INTO tracked_symbols (title, paused, created_at) VALUES (?, ?, ?) ON CONFLICT (title) DO NOTHING;`
+
+	created := formatTime(time.Now())
+	res, err := s.db.ExecContext(ctx, query, symbol.Title, symbol.Paused, created)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Symbol was not added: %s", symbol.Title))
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return errors.Wrap(entities.ErrConflict, fmt.Sprintf("Symbol is already tracked: %s", symbol.Title))
+	}
+	symbol.CreateTime, _ = parseTime(created)
+	return nil
+}
+
+func (s *Storage) GetSymbols(ctx context.Context) (_ []entities.Symbol, err error) {
+	ctx, span := startSpan(ctx, "GetSymbols")
+	defer tracing.End(span, &err)
+
+	query := `SELECT title, paused, created_at FROM tracked_symbols ORDER BY title;`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols")
+	}
+	defer rows.Close()
+
+	var symbols []entities.Symbol
+	for rows.Next() {
+		symbol, err := scanSymbol(rows)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols")
+		}
+		symbols = append(symbols, *symbol)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols")
+	}
+
+	return symbols, nil
+}
+
+func (s *Storage) SetSymbolPaused(ctx context.Context, title string, paused bool) (_ *entities.Symbol, err error) {
+	ctx, span := startSpan(ctx, "SetSymbolPaused")
+	defer tracing.End(span, &err)
+
+	query := `UPDATE tracked_symbols SET paused = ?2 WHERE title = ?1 RETURNING title, paused, created_at;`
+
+	symbol, err := scanSymbol(s.db.QueryRowContext(ctx, query, title, paused))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to update symbol: %s", title))
+	}
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to update symbol: %s", title))
+	}
+	return symbol, nil
+}
+
+func (s *Storage) DeleteSymbol(ctx context.Context, title string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteSymbol")
+	defer tracing.End(span, &err)
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM tracked_symbols WHERE title = ?;`, title)
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, fmt.Sprintf("Unable to delete symbol: %s", title))
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return errors.Wrap(entities.ErrNotFound, fmt.Sprintf("Unable to delete symbol: %s", title))
+	}
+	return nil
+}
+
+// row is a *sql.Row or *sql.Rows.
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSymbol(r row) (*entities.Symbol, error) {
+	var symbol entities.Symbol
+	var created string
+	if err := r.Scan(&symbol.Title, &symbol.Paused, &created); err != nil {
+		return nil, err
+	}
+	var err error
+	if symbol.CreateTime, err = parseTime(created); err != nil {
+		return nil, err
+	}
+	return &symbol, nil
+}
@@ -0,0 +1,317 @@
+// Package storagetest is the conformance suite every storage driver must
+// pass, so the service behaves the same whichever database it runs on.
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/usecases"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Storage is what the service needs from a driver.
+type Storage interface {
+	usecases.Storage
+	usecases.AlertStorage
+}
+
+// start is whole seconds in UTC: every driver stores it exactly.
+var start = time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+
+// Run runs the suite; newStorage returns an empty, migrated storage and is
+// called once per test.
+func Run(t *testing.T, newStorage func(t *testing.T) Storage) {
+	tests := map[string]func(t *testing.T, s Storage){
+		"Store":      testStore,
+		"Get":        testGet,
+		"GetCandles": testGetCandles,
+		"GetAfter":   testGetAfter,
+		"GetUpdates": testGetUpdates,
+		"Symbols":    testSymbols,
+		"Alerts":     testAlerts,
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newStorage(t))
+		})
+	}
+}
+
+func coin(title, quote string, price int64, minutes int) entities.Coin {
+	return entities.Coin{
+		Title: title, Quote: quote, Price: decimal.NewFromInt(price), Provider: "test",
+		CreateTime: start.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+// seed stores the prices most tests read.
+func seed(t *testing.T, s Storage) {
+	t.Helper()
+	require.NoError(t, s.Store(context.Background(), []entities.Coin{
+		coin("BTC", "USD", 100, 0),
+		coin("BTC", "EUR", 90, 0),
+		coin("ETH", "USD", 10, 0),
+		coin("BTC", "USD", 130, 10),
+		coin("BTC", "USD", 110, 20),
+		// опоздавшая цена не становится последней.
+		coin("BTC", "USD", 120, 5),
+	}))
+}
+
+func prices(coins []entities.Coin) []string {
+	var out []string
+	for _, c := range coins {
+		out = append(out, c.Title+"/"+c.Quote+":"+c.Price.String())
+	}
+	return out
+}
+
+func testStore(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	coins := []entities.Coin{coin("BTC", "USD", 1, 0), coin("BTC", "EUR", 1, 0)}
+	require.NoError(t, s.Store(ctx, coins))
+	assert.NotZero(t, coins[0].ID)
+	assert.Less(t, coins[0].ID, coins[1].ID)
+
+	more := []entities.Coin{coin("BTC", "USD", 2, 1)}
+	require.NoError(t, s.Store(ctx, more))
+	assert.Less(t, coins[1].ID, more[0].ID)
+
+	require.NoError(t, s.Store(ctx, nil))
+
+	stored, err := s.Get(ctx, []string{"BTC"}, usecases.WithQuotes("USD"))
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "2", stored[0].Price.String())
+	assert.Equal(t, "test", stored[0].Provider)
+	assert.Equal(t, start.Add(time.Minute), stored[0].CreateTime)
+
+	t.Run("exact price", func(t *testing.T) {
+		price := decimal.RequireFromString("0.000000012345678901234567")
+		require.NoError(t, s.Store(ctx, []entities.Coin{{Title: "SHIB", Quote: "BTC", Price: price, CreateTime: start}}))
+		stored, err := s.Get(ctx, []string{"SHIB"})
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.True(t, price.Equal(stored[0].Price), stored[0].Price.String())
+	})
+}
+
+func testGet(t *testing.T, s Storage) {
+	seed(t, s)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		titles  []string
+		options []usecases.Option
+		want    []string
+	}{
+		{name: "latest", titles: []string{"ETH", "BTC"}, want: []string{"ETH/USD:10", "BTC/EUR:90", "BTC/USD:110"}},
+		{name: "quotes", titles: []string{"BTC"}, options: []usecases.Option{usecases.WithQuotes("USD")}, want: []string{"BTC/USD:110"}},
+		{name: "max", titles: []string{"BTC"}, options: []usecases.Option{usecases.WithMaxFunc()}, want: []string{"BTC/EUR:90", "BTC/USD:130"}},
+		{name: "min", titles: []string{"BTC"}, options: []usecases.Option{usecases.WithMinFunc(), usecases.WithQuotes("USD")}, want: []string{"BTC/USD:100"}},
+		{name: "avg", titles: []string{"BTC"}, options: []usecases.Option{usecases.WithAvgFunc(), usecases.WithQuotes("USD")}, want: []string{"BTC/USD:115"}},
+		{
+			name:    "last in range",
+			titles:  []string{"BTC"},
+			options: []usecases.Option{usecases.WithQuotes("USD"), usecases.WithRange(start, start.Add(15*time.Minute))},
+			want:    []string{"BTC/USD:130"},
+		},
+		{
+			name:    "max in range",
+			titles:  []string{"BTC"},
+			options: []usecases.Option{usecases.WithMaxFunc(), usecases.WithQuotes("USD"), usecases.WithRange(start.Add(15*time.Minute), time.Time{})},
+			want:    []string{"BTC/USD:110"},
+		},
+		{
+			name:    "empty range",
+			titles:  []string{"ETH", "BTC"},
+			options: []usecases.Option{usecases.WithMaxFunc(), usecases.WithRange(start.Add(15*time.Minute), time.Time{})},
+			want:    []string{"BTC/USD:110"},
+		},
+		{name: "no titles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coins, err := s.Get(ctx, tt.titles, tt.options...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, prices(coins))
+		})
+	}
+
+	t.Run("aggregate covers range", func(t *testing.T) {
+		coins, err := s.Get(ctx, []string{"BTC"}, usecases.WithMaxFunc(), usecases.WithQuotes("USD"))
+		require.NoError(t, err)
+		require.Len(t, coins, 1)
+		assert.Equal(t, start, coins[0].From)
+		assert.Equal(t, start.Add(20*time.Minute), coins[0].CreateTime)
+	})
+
+	t.Run("unknown title", func(t *testing.T) {
+		_, err := s.Get(ctx, []string{"BTC", "DOGE"})
+		assert.ErrorIs(t, err, entities.ErrNotFound)
+	})
+
+	t.Run("unknown quote", func(t *testing.T) {
+		_, err := s.Get(ctx, []string{"ETH"}, usecases.WithQuotes("EUR"), usecases.WithRange(start, time.Time{}))
+		assert.ErrorIs(t, err, entities.ErrNotFound)
+	})
+}
+
+func testGetCandles(t *testing.T, s Storage) {
+	seed(t, s)
+	ctx := context.Background()
+
+	candles, err := s.GetCandles(ctx, []string{"ETH", "BTC"}, 15*time.Minute, usecases.WithQuotes("USD"))
+	require.NoError(t, err)
+	candle := func(title string, minutes int, open, high, low, close int64) entities.Candle {
+		return entities.Candle{
+			Title: title, Quote: "USD", Start: start.Add(time.Duration(minutes) * time.Minute), Interval: 15 * time.Minute,
+			Open: decimal.NewFromInt(open), High: decimal.NewFromInt(high), Low: decimal.NewFromInt(low), Close: decimal.NewFromInt(close),
+		}
+	}
+	want := []entities.Candle{
+		candle("ETH", 0, 10, 10, 10, 10),
+		candle("BTC", 0, 100, 130, 100, 130),
+		candle("BTC", 15, 110, 110, 110, 110),
+	}
+	require.Len(t, candles, len(want))
+	for i := range want {
+		assert.Equal(t, want[i].Title, candles[i].Title)
+		assert.Equal(t, want[i].Start, candles[i].Start)
+		assert.Equal(t, want[i].Interval, candles[i].Interval)
+		for _, p := range [][2]decimal.Decimal{
+			{want[i].Open, candles[i].Open}, {want[i].High, candles[i].High},
+			{want[i].Low, candles[i].Low}, {want[i].Close, candles[i].Close},
+		} {
+			assert.True(t, p[0].Equal(p[1]), "candle %d: want %s, got %s", i, p[0], p[1])
+		}
+	}
+
+	candles, err = s.GetCandles(ctx, []string{"BTC"}, time.Hour, usecases.WithRange(start.Add(time.Hour), time.Time{}))
+	require.NoError(t, err)
+	assert.Empty(t, candles)
+}
+
+func testGetAfter(t *testing.T, s Storage) {
+	ctx := context.Background()
+	last, err := s.LastID(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, last)
+
+	coins := []entities.Coin{
+		coin("BTC", "USD", 1, 0), coin("ETH", "USD", 1, 0), coin("BTC", "EUR", 1, 0), coin("BTC", "USD", 2, 1), coin("BTC", "USD", 3, 2),
+	}
+	require.NoError(t, s.Store(ctx, coins))
+
+	last, err = s.LastID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, coins[4].ID, last)
+
+	after, err := s.GetAfter(ctx, coins[0].ID, []string{"BTC"}, 2, usecases.WithQuotes("USD"))
+	require.NoError(t, err)
+	require.Len(t, after, 2)
+	assert.Equal(t, coins[3].ID, after[0].ID)
+	assert.Equal(t, coins[4].ID, after[1].ID)
+	assert.Equal(t, "2", after[0].Price.String())
+
+	all, err := s.GetAfter(ctx, 0, nil, 10)
+	require.NoError(t, err)
+	assert.Len(t, all, len(coins))
+
+	none, err := s.GetAfter(ctx, coins[4].ID, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func testGetUpdates(t *testing.T, s Storage) {
+	seed(t, s)
+
+	updates, err := s.GetUpdates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []entities.SymbolStatus{
+		{Title: "BTC", Quote: "EUR", UpdateTime: start},
+		{Title: "BTC", Quote: "USD", UpdateTime: start.Add(20 * time.Minute)},
+		{Title: "ETH", Quote: "USD", UpdateTime: start},
+	}, updates)
+}
+
+func testSymbols(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, s.AddSymbol(ctx, &entities.Symbol{Title: "ETH"}))
+	btc := &entities.Symbol{Title: "BTC"}
+	require.NoError(t, s.AddSymbol(ctx, btc))
+	assert.False(t, btc.CreateTime.IsZero())
+	assert.ErrorIs(t, s.AddSymbol(ctx, &entities.Symbol{Title: "BTC"}), entities.ErrConflict)
+
+	paused, err := s.SetSymbolPaused(ctx, "BTC", true)
+	require.NoError(t, err)
+	assert.True(t, paused.Paused)
+	_, err = s.SetSymbolPaused(ctx, "DOGE", true)
+	assert.ErrorIs(t, err, entities.ErrNotFound)
+
+	require.NoError(t, s.DeleteSymbol(ctx, "ETH"))
+	assert.ErrorIs(t, s.DeleteSymbol(ctx, "ETH"), entities.ErrNotFound)
+
+	symbols, err := s.GetSymbols(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Symbol{*paused}, symbols)
+}
+
+func testAlerts(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	alert := &entities.Alert{
+		Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.RequireFromString("100.5"),
+		Cooldown: time.Hour, WebhookURL: "https://example.com/hook", Secret: "secret",
+	}
+	require.NoError(t, s.CreateAlert(ctx, alert))
+	assert.NotZero(t, alert.ID)
+	assert.False(t, alert.CreateTime.IsZero())
+
+	got, err := s.GetAlert(ctx, alert.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "100.5", got.Threshold.String())
+	assert.Equal(t, time.Hour, got.Cooldown)
+	assert.True(t, got.LastFiredAt.IsZero())
+	assert.Equal(t, alert.CreateTime, got.CreateTime)
+
+	require.NoError(t, s.SetAlertState(ctx, alert.ID, decimal.NewFromInt(101), start))
+	got, err = s.GetAlert(ctx, alert.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "101", got.LastPrice.String())
+	assert.Equal(t, start, got.LastFiredAt)
+
+	got.Condition = entities.AlertBelow
+	require.NoError(t, s.UpdateAlert(ctx, got))
+	alerts, err := s.GetAlerts(ctx)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, entities.AlertBelow, alerts[0].Condition)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		require.NoError(t, s.StoreDelivery(ctx, &entities.Delivery{AlertID: alert.ID, Attempt: attempt, StatusCode: 502, CreateTime: start}))
+	}
+	deliveries, err := s.GetDeliveries(ctx, alert.ID, 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, 3, deliveries[0].Attempt)
+	assert.Equal(t, start, deliveries[0].CreateTime)
+
+	require.NoError(t, s.DeleteAlert(ctx, alert.ID))
+	_, err = s.GetAlert(ctx, alert.ID)
+	assert.ErrorIs(t, err, entities.ErrNotFound)
+	assert.ErrorIs(t, s.DeleteAlert(ctx, alert.ID), entities.ErrNotFound)
+	assert.ErrorIs(t, s.UpdateAlert(ctx, alert), entities.ErrNotFound)
+	deliveries, err = s.GetDeliveries(ctx, alert.ID, 2)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
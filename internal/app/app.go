@@ -2,92 +2,307 @@ package app
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"currency/internal/adapters/client/coindesk"
-	"currency/internal/adapters/storage/postgres"
+	"currency/internal/adapters/client"
+	"currency/internal/adapters/client/resilience"
+	"currency/internal/adapters/notifier/webhook"
+	"currency/internal/election"
+	"currency/internal/lifecycle"
+	"currency/internal/logging"
+	"currency/internal/metrics"
+	grpcpublic "currency/internal/ports/grpc/public"
 	"currency/internal/ports/http/public"
+	"currency/internal/retention"
+	"currency/internal/scheduler"
+	"currency/internal/tracing"
 	"currency/internal/usecases"
 
 	"github.com/pkg/errors"
-	"github.com/robfig/cron/v3"
 	"github.com/spf13/viper"
 )
 
+const defaultShutdownTimeout = 15 * time.Second
+
 type Config struct {
-	port          string
-	connStr       string
-	url           string
+	port     string
+	grpcPort string
+	driver   string
+	connStr  string
+	// path is the database file of the sqlite driver.
+	path          string
+	strategy      string
+	providers     []client.ProviderConfig
+	httpConfig    resilience.Config
 	baseUrlParams []string
+	quotes        []string
+	alertConfig   usecases.AlertConfig
+	// webhookTimeout bounds one alert delivery attempt.
+	webhookTimeout time.Duration
+	tracing        tracing.Config
+	logging        logging.Config
+	health         usecases.HealthConfig
+	// shutdownTimeout bounds the graceful shutdown of every component.
+	shutdownTimeout time.Duration
+	// adminToken guards the admin API; empty disables it.
+	adminToken string
+	scheduler  scheduler.Config
+	election   election.Config
+	retention  retention.Config
+	// migrate applies the pending migrations on start.
+	migrate bool
 }
 
-func NewConfig() *Config {
+func NewConfig() (*Config, error) {
 	port := viper.GetString("port")
+	grpcPort := viper.GetString("grpcPort")
+	viper.SetDefault("database.driver", DriverPostgres)
 	connStr := viper.GetString("database.connStr")
-	url := viper.GetString("externalAPI.url")
+	viper.SetDefault("database.migrate", true)
+	strategy := viper.GetString("externalAPI.strategy")
 	baseUrlParams := viper.GetStringSlice("externalAPI.baseUrlParams.fsyms")
+	quotes := viper.GetStringSlice("externalAPI.baseUrlParams.tsyms")
 
-	return &Config{port: port, connStr: connStr, url: url, baseUrlParams: baseUrlParams}
+	var providers []client.ProviderConfig
+	if err := viper.UnmarshalKey("externalAPI.providers", &providers); err != nil {
+		return nil, errors.Wrap(err, "read providers failed")
+	}
+	// Ключи, которых нет в конфиге, остаются по умолчанию; maxRetries: 0 отключает повторы.
+	httpConfig := resilience.DefaultConfig
+	if err := viper.UnmarshalKey("externalAPI.http", &httpConfig); err != nil {
+		return nil, errors.Wrap(err, "read http config failed")
+	}
+	var alertConfig usecases.AlertConfig
+	if err := viper.UnmarshalKey("alerts", &alertConfig); err != nil {
+		return nil, errors.Wrap(err, "read alerts config failed")
+	}
+	var tracingConfig tracing.Config
+	if err := viper.UnmarshalKey("tracing", &tracingConfig); err != nil {
+		return nil, errors.Wrap(err, "read tracing config failed")
+	}
+	var healthConfig usecases.HealthConfig
+	if err := viper.UnmarshalKey("health", &healthConfig); err != nil {
+		return nil, errors.Wrap(err, "read health config failed")
+	}
+	var schedulerConfig scheduler.Config
+	if err := viper.UnmarshalKey("scheduler", &schedulerConfig); err != nil {
+		return nil, errors.Wrap(err, "read scheduler config failed")
+	}
+	var electionConfig election.Config
+	if err := viper.UnmarshalKey("election", &electionConfig); err != nil {
+		return nil, errors.Wrap(err, "read election config failed")
+	}
+	var retentionConfig retention.Config
+	if err := viper.UnmarshalKey("retention", &retentionConfig); err != nil {
+		return nil, errors.Wrap(err, "read retention config failed")
+	}
+	var loggingConfig logging.Config
+	if err := viper.UnmarshalKey("logging", &loggingConfig); err != nil {
+		return nil, errors.Wrap(err, "read logging config failed")
+	}
+	if err := viper.BindEnv("admin.token", "ADMIN_TOKEN"); err != nil {
+		return nil, errors.Wrap(err, "bind admin token failed")
+	}
+	shutdownTimeout := viper.GetDuration("shutdownTimeout")
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	// Configs predating providers only have externalAPI.url.
+	if url := viper.GetString("externalAPI.url"); len(providers) == 0 && url != "" {
+		providers = append(providers, client.ProviderConfig{Name: "cryptocompare", URL: url})
+	}
+
+	return &Config{
+		port:            port,
+		grpcPort:        grpcPort,
+		driver:          viper.GetString("database.driver"),
+		connStr:         connStr,
+		path:            viper.GetString("database.path"),
+		migrate:         viper.GetBool("database.migrate"),
+		strategy:        strategy,
+		providers:       providers,
+		httpConfig:      httpConfig,
+		baseUrlParams:   baseUrlParams,
+		quotes:          quotes,
+		alertConfig:     alertConfig,
+		webhookTimeout:  viper.GetDuration("alerts.timeout"),
+		tracing:         tracingConfig,
+		logging:         loggingConfig,
+		health:          healthConfig,
+		shutdownTimeout: shutdownTimeout,
+		adminToken:      viper.GetString("admin.token"),
+		scheduler:       schedulerConfig,
+		election:        electionConfig,
+		retention:       retentionConfig,
+	}, nil
 }
 
-func Run() error {
+// load reads config.yaml and creates the logger it configures.
+func load() (*Config, *slog.Logger, error) {
 	viper.AddConfigPath("deployment/config")
 	viper.SetConfigName("config")
 
 	err := viper.ReadInConfig()
 	if err != nil {
-		return errors.Wrap(err, "read config failed")
+		return nil, nil, errors.Wrap(err, "read config failed")
 	}
 
-	config := NewConfig()
+	config, err := NewConfig()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "create config failed")
+	}
+
+	logger, err := logging.New(config.logging, os.Stdout)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "create logger failed")
+	}
+	return config, logger, nil
+}
+
+func Run() error {
+	config, logger, err := load()
+	if err != nil {
+		return err
+	}
+
+	// Первый SIGINT/SIGTERM запускает graceful shutdown, второй завершает процесс сразу.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	return run(ctx, config, logger)
+}
+
+// run starts every component and blocks until ctx is done and they stop.
+func run(ctx context.Context, config *Config, logger *slog.Logger) error {
+	manager, err := lifecycle.NewManager(logger, config.shutdownTimeout)
+	if err != nil {
+		return errors.Wrap(err, "create lifecycle manager failed")
+	}
 
-	ctx := context.Background()
+	shutdownTracing, err := tracing.Init(ctx, "currency", config.tracing)
+	if err != nil {
+		return errors.Wrap(err, "init tracing failed")
+	}
+	manager.Add(lifecycle.Component{Name: "tracing", Stop: shutdownTracing})
 
-	storage, err := postgres.NewStorage(ctx, config.connStr)
+	db, err := openDatabase(ctx, config, logger)
 	if err != nil {
 		return errors.Wrap(err, "create storage failed")
 	}
+	manager.Add(lifecycle.Component{Name: "storage", Stop: func(context.Context) error {
+		db.close()
+		return nil
+	}})
+	storage := db.storage
+
+	if config.migrate && db.migrator != nil {
+		if _, err := db.migrator.Up(ctx); err != nil {
+			return errors.Wrap(err, "migrate failed")
+		}
+	}
+	// Выборы лидера держат advisory lock в postgres, а агрегаты retention есть только в нём:
+	// sqlite и memory обслуживают один экземпляр и хранят сырые цены.
+	if config.election.Enabled && db.postgres == nil {
+		logger.Warn("leader election needs the postgres driver, running without it", slog.String("driver", config.driver))
+		config.election.Enabled = false
+	}
+	if config.retention.Enabled && db.postgres == nil {
+		logger.Warn("retention needs the postgres driver, running without it", slog.String("driver", config.driver))
+		config.retention.Enabled = false
+	}
 
-	client, err := coindesk.NewClient(config.url)
+	priceClient, err := client.New(config.strategy, config.providers, config.quotes, config.httpConfig, logger)
 	if err != nil {
 		return errors.Wrap(err, "create client failed")
 	}
+	metrics.SetBreakers(priceClient.States)
 
-	service, err := usecases.NewService(storage, client)
+	service, err := usecases.NewService(storage, priceClient, logger)
 	if err != nil {
 		return errors.Wrap(err, "create service failed")
 	}
+	service.SetHealth(config.health)
 
-	server, err := public.NewServer(service, config.port)
+	notifier, err := webhook.NewNotifier(config.webhookTimeout)
 	if err != nil {
-		return errors.Wrap(err, "create server failed")
+		return errors.Wrap(err, "create notifier failed")
+	}
+	if err := service.SetAlerts(storage, notifier, config.alertConfig); err != nil {
+		return errors.Wrap(err, "set alerts failed")
 	}
+	manager.Add(lifecycle.Component{Name: "service", Stop: service.Shutdown})
 
-	go runCrone(service, config.baseUrlParams)
+	// fsyms из конфига нужны только для первого запуска, дальше символами управляет admin API.
+	if err := service.SeedSymbols(ctx, config.baseUrlParams); err != nil {
+		return errors.Wrap(err, "seed symbols failed")
+	}
 
-	err = server.Run()
+	priceScheduler, err := scheduler.New(service, logger, config.scheduler, config.quotes)
 	if err != nil {
-		return errors.Wrap(err, "server run failed")
+		return errors.Wrap(err, "create scheduler failed")
+	}
+	var retainer *retention.Retainer
+	if config.retention.Enabled {
+		retainer, err = retention.New(db.postgres, logger, config.retention)
+		if err != nil {
+			return errors.Wrap(err, "create retainer failed")
+		}
 	}
 
-	return nil
-}
+	// С выборами лидера цены загружает только одна реплика, остальные только отдают их из базы.
+	if config.election.Enabled {
+		lock, err := db.postgres.NewLock(config.election.LockID, election.Instance(config.election))
+		if err != nil {
+			return errors.Wrap(err, "create leader lock failed")
+		}
+		elector, err := election.New(lock, logger, config.election)
+		if err != nil {
+			return errors.Wrap(err, "create elector failed")
+		}
+		service.SetLeader(elector)
+		priceScheduler.SetLeader(elector)
+		if retainer != nil {
+			retainer.SetLeader(elector)
+		}
+		elector.OnElected(priceScheduler.Elected)
+		manager.Add(lifecycle.Component{Name: "election", Run: elector.Run, Stop: elector.Stop})
 
-func runCrone(service *usecases.Service, titles []string) {
-	ctx := context.Background()
+		relay, err := usecases.NewRelay(service, config.election.Relay)
+		if err != nil {
+			return errors.Wrap(err, "create relay failed")
+		}
+		manager.Add(lifecycle.Component{Name: "relay", Run: relay.Run, Stop: relay.Stop})
+	}
+	manager.Add(lifecycle.Component{Name: "scheduler", Run: priceScheduler.Run, Stop: priceScheduler.Stop})
+	if retainer != nil {
+		manager.Add(lifecycle.Component{Name: "retention", Run: retainer.Run, Stop: retainer.Stop})
+	}
 
-	_, err := service.GetCoinsFromAPI(ctx, titles...)
+	server, err := public.NewServer(service, config.port, logger)
 	if err != nil {
-		log.Println(err)
+		return errors.Wrap(err, "create server failed")
 	}
+	server.SetAdminToken(config.adminToken)
+	server.SetScheduler(priceScheduler)
+	manager.Add(lifecycle.Component{Name: "http server", Run: server.Run, Stop: server.Shutdown})
 
-	c := cron.New()
-	updateFunc := func() {
-		_, err := service.GetCoinsFromAPI(ctx)
-		if err != nil {
-			log.Println(err)
-		}
+	grpcServer, err := grpcpublic.NewServer(service, config.grpcPort, logger)
+	if err != nil {
+		return errors.Wrap(err, "create grpc server failed")
 	}
-	c.AddFunc("@every 1m", updateFunc)
-	c.Run()
+	manager.Add(lifecycle.Component{Name: "grpc server", Run: grpcServer.Run, Stop: grpcServer.Shutdown})
+
+	logger.Info("listening", slog.String("port", config.port), slog.String("grpcPort", config.grpcPort))
+
+	// Компоненты останавливаются в обратном порядке: сначала серверы, потом
+	// retention, планировщик, выборы лидера, доставка алертов, пул соединений и трейсинг.
+	return manager.Run(ctx)
 }
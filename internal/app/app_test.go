@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"currency/internal/logging"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func freePort(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	return strconv.Itoa(lis.Addr().(*net.TCPAddr).Port)
+}
+
+// TestRun_SQLite starts the app with the shipped config.yaml, which enables
+// election and retention, on the sqlite driver.
+func TestRun_SQLite(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.AddConfigPath("../../deployment/config")
+
+	config, _, err := load()
+	require.NoError(t, err)
+	require.True(t, config.election.Enabled)
+	require.True(t, config.retention.Enabled)
+	config.driver = DriverSQLite
+	config.path = filepath.Join(t.TempDir(), "currency.db")
+	config.port, config.grpcPort = freePort(t), freePort(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, config, logging.Nop())
+	}()
+
+	url := "http://127.0.0.1:" + config.port + "/healthz"
+	var runErr error
+	stopped := false
+	require.Eventually(t, func() bool {
+		select {
+		case runErr = <-done:
+			stopped = true
+			return true
+		default:
+		}
+		resp, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 10*time.Second, 50*time.Millisecond)
+	require.False(t, stopped, "app stopped: %v", runErr)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(config.shutdownTimeout + time.Second):
+		t.Fatal("app didn't stop")
+	}
+}
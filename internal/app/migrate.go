@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+const migrateUsage = "usage: currency migrate up | down [steps] | status"
+
+// Migrate runs the migrate subcommand with args: up applies the pending
+// migrations, down rolls back the last steps ones, one by default, and
+// status lists them.
+func Migrate(args []string) error {
+	steps, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	config, logger, err := load()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := openDatabase(ctx, config, logger)
+	if err != nil {
+		return errors.Wrap(err, "create storage failed")
+	}
+	defer db.close()
+	if db.migrator == nil {
+		return errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("nothing to migrate with the %s driver", config.driver))
+	}
+	migrator := db.migrator
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			return errors.Wrap(err, "migrate up failed")
+		}
+		fmt.Printf("applied %d migrations\n", count)
+	case "down":
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return errors.Wrap(err, "migrate down failed")
+		}
+		fmt.Printf("rolled back %d migrations\n", count)
+	default:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return errors.Wrap(err, "migrate status failed")
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+	return nil
+}
+
+// parseMigrateArgs checks the subcommand and returns the steps of down.
+func parseMigrateArgs(args []string) (int, error) {
+	switch {
+	case len(args) == 1 && (args[0] == "up" || args[0] == "status"):
+		return 0, nil
+	case len(args) == 1 && args[0] == "down":
+		return 1, nil
+	case len(args) == 2 && args[0] == "down":
+		steps, err := strconv.Atoi(args[1])
+		if err == nil && steps > 0 {
+			return steps, nil
+		}
+	}
+	return 0, errors.Wrap(entities.ErrInvalidParams, migrateUsage)
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"currency/deployment/migrations"
+	"currency/internal/adapters/storage/memory"
+	"currency/internal/adapters/storage/postgres"
+	"currency/internal/adapters/storage/sqlite"
+	"currency/internal/entities"
+	"currency/internal/usecases"
+
+	"github.com/pkg/errors"
+)
+
+// Database drivers of database.driver in config.yaml.
+const (
+	DriverPostgres = "postgres"
+	// DriverSQLite keeps everything in the file at database.path, for the
+	// single instance deployments that can't run Postgres.
+	DriverSQLite = "sqlite"
+	// DriverMemory keeps everything in memory, for development and tests.
+	DriverMemory = "memory"
+)
+
+// storage is what the service needs from every driver.
+type storage interface {
+	usecases.Storage
+	usecases.AlertStorage
+}
+
+// migrator applies the schema migrations of a driver.
+type migrator interface {
+	Up(ctx context.Context) (int, error)
+	Down(ctx context.Context, steps int) (int, error)
+	Status(ctx context.Context) ([]migrations.Status, error)
+}
+
+// database is the storage of the configured driver.
+type database struct {
+	storage storage
+	// postgres is nil unless the driver is postgres: retention and leader
+	// election need it.
+	postgres *postgres.Storage
+	// migrator is nil if the driver has no schema.
+	migrator migrator
+	close    func()
+}
+
+func openDatabase(ctx context.Context, config *Config, logger *slog.Logger) (*database, error) {
+	switch config.driver {
+	case DriverPostgres:
+		pg, err := postgres.NewStorage(ctx, config.connStr, logger)
+		if err != nil {
+			return nil, err
+		}
+		m, err := pg.NewMigrator(migrations.Postgres, "postgres", logger)
+		if err != nil {
+			pg.Close()
+			return nil, err
+		}
+		return &database{storage: pg, postgres: pg, migrator: m, close: pg.Close}, nil
+	case DriverSQLite:
+		s, err := sqlite.NewStorage(ctx, config.path, logger)
+		if err != nil {
+			return nil, err
+		}
+		m, err := s.NewMigrator(migrations.SQLite, "sqlite", logger)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		return &database{storage: s, migrator: m, close: s.Close}, nil
+	case DriverMemory:
+		logger.Warn("prices are kept in memory and lost on restart")
+		return &database{storage: memory.NewStorage(), close: func() {}}, nil
+	default:
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("database driver: %s", config.driver))
+	}
+}
@@ -0,0 +1,185 @@
+// Package election picks the replica that ingests prices. The leader holds a
+// lock until it stops or loses its connection, then another replica takes it.
+package election
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/metrics"
+
+	"github.com/pkg/errors"
+)
+
+const DefaultInterval = 5 * time.Second
+
+// Config is election in config.yaml.
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Instance identifies this replica, the hostname by default.
+	Instance string `mapstructure:"instance"`
+	// Interval is how often a follower tries to take the lock and the leader
+	// checks it still holds it.
+	Interval time.Duration `mapstructure:"interval"`
+	LockID   int64         `mapstructure:"lockId"`
+	// Relay is how often every replica reads the stored prices to publish
+	// them to its streams.
+	Relay time.Duration `mapstructure:"relay"`
+}
+
+//go:generate mockgen -source=election.go -destination=./mocks/locker_mock.go -package=mock
+type Locker interface {
+	// TryLock takes the lock or checks that it is still held; false means
+	// another instance holds it.
+	TryLock(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context) error
+	// Holder returns the instance holding the lock, empty if none does.
+	Holder(ctx context.Context) (string, error)
+}
+
+// Elector campaigns for the lock until Stop.
+type Elector struct {
+	locker   Locker
+	logger   *slog.Logger
+	instance string
+	interval time.Duration
+	leader   atomic.Bool
+
+	mu        sync.Mutex
+	onElected []func()
+
+	stopping chan struct{}
+	done     chan struct{}
+}
+
+func New(locker Locker, logger *slog.Logger, cfg Config) (*Elector, error) {
+	if locker == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "locker is nil")
+	}
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	if cfg.Interval < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("interval: %s", cfg.Interval))
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+
+	return &Elector{
+		locker:   locker,
+		logger:   logger,
+		instance: Instance(cfg),
+		interval: cfg.Interval,
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Instance returns the configured instance, or the hostname when none is.
+func Instance(cfg Config) string {
+	if cfg.Instance != "" {
+		return cfg.Instance
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		return hostname
+	}
+	return fmt.Sprintf("pid-%d", os.Getpid())
+}
+
+func (e *Elector) Instance() string {
+	return e.instance
+}
+
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Leader returns the instance holding the lock.
+func (e *Elector) Leader(ctx context.Context) (string, error) {
+	if e.IsLeader() {
+		return e.instance, nil
+	}
+	return e.locker.Holder(ctx)
+}
+
+// OnElected registers f to be called every time this instance becomes the
+// leader. f runs in the election loop, so it should return quickly.
+func (e *Elector) OnElected(f func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onElected = append(e.onElected, f)
+}
+
+// Run campaigns every interval until Stop.
+func (e *Elector) Run() error {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.campaign()
+
+		select {
+		case <-ticker.C:
+		case <-e.stopping:
+			return nil
+		}
+	}
+}
+
+func (e *Elector) campaign() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.interval)
+	defer cancel()
+
+	held, err := e.locker.TryLock(ctx)
+	if err != nil {
+		e.logger.Warn("leader election failed", slog.String("instance", e.instance), slog.Any("error", err))
+	}
+	e.setLeader(held && err == nil)
+}
+
+func (e *Elector) setLeader(leader bool) {
+	if e.leader.Swap(leader) == leader {
+		return
+	}
+	if leader {
+		metrics.Leader.Set(1)
+		e.logger.Info("elected leader", slog.String("instance", e.instance))
+
+		e.mu.Lock()
+		callbacks := append([]func(){}, e.onElected...)
+		e.mu.Unlock()
+		for _, f := range callbacks {
+			f()
+		}
+		return
+	}
+	metrics.Leader.Set(0)
+	e.logger.Warn("lost leadership", slog.String("instance", e.instance))
+}
+
+// Stop ends the campaign and releases the lock so another instance takes
+// over without waiting for this connection to drop.
+func (e *Elector) Stop(ctx context.Context) error {
+	close(e.stopping)
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return errors.Wrap(entities.ErrInternalServer, "leader election is still running")
+	}
+
+	e.leader.Store(false)
+	metrics.Leader.Set(0)
+	if err := e.locker.Unlock(ctx); err != nil {
+		return errors.Wrap(err, "release leader lock")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package election_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"currency/internal/election"
+	mock "currency/internal/election/mocks"
+	"currency/internal/entities"
+	"currency/internal/logging"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := election.New(nil, logging.Nop(), election.Config{})
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+	_, err = election.New(mock.NewMockLocker(ctrl), nil, election.Config{})
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+	_, err = election.New(mock.NewMockLocker(ctrl), logging.Nop(), election.Config{Interval: -time.Second})
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+
+	e, err := election.New(mock.NewMockLocker(ctrl), logging.Nop(), election.Config{Instance: "coinapp-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "coinapp-1", e.Instance())
+	assert.NotEmpty(t, election.Instance(election.Config{}))
+}
+
+func TestElector(t *testing.T) {
+	t.Run("failover", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		locker := mock.NewMockLocker(ctrl)
+		var attempt atomic.Int32
+		// follower, then leader until the lock connection drops, then leader again
+		locker.EXPECT().TryLock(gomock.Any()).DoAndReturn(func(context.Context) (bool, error) {
+			switch n := attempt.Add(1); {
+			case n == 1:
+				return false, nil
+			case n == 4:
+				return false, errors.Wrap(entities.ErrInternalServer, "Lost leader lock connection")
+			default:
+				return true, nil
+			}
+		}).MinTimes(5)
+		locker.EXPECT().Holder(gomock.Any()).Return("coinapp-2", nil).AnyTimes()
+		locker.EXPECT().Unlock(gomock.Any()).Return(nil)
+
+		e, err := election.New(locker, logging.Nop(), election.Config{Instance: "coinapp-1", Interval: 5 * time.Millisecond})
+		require.NoError(t, err)
+		var elected atomic.Int32
+		e.OnElected(func() { elected.Add(1) })
+
+		go func() { _ = e.Run() }()
+		assert.Eventually(t, func() bool { return attempt.Load() >= 5 && e.IsLeader() }, time.Second, time.Millisecond)
+		assert.Eventually(t, func() bool { return elected.Load() == 2 }, time.Second, time.Millisecond)
+
+		leader, err := e.Leader(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "coinapp-1", leader)
+
+		require.NoError(t, e.Stop(context.Background()))
+		assert.False(t, e.IsLeader())
+	})
+
+	t.Run("follower", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		locker := mock.NewMockLocker(ctrl)
+		locker.EXPECT().TryLock(gomock.Any()).Return(false, nil).MinTimes(1)
+		locker.EXPECT().Holder(gomock.Any()).Return("coinapp-2", nil)
+		locker.EXPECT().Unlock(gomock.Any()).Return(nil)
+
+		e, err := election.New(locker, logging.Nop(), election.Config{Instance: "coinapp-1", Interval: time.Hour})
+		require.NoError(t, err)
+		e.OnElected(func() { t.Error("follower was elected") })
+
+		go func() { _ = e.Run() }()
+		time.Sleep(10 * time.Millisecond)
+		assert.False(t, e.IsLeader())
+
+		leader, err := e.Leader(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "coinapp-2", leader)
+
+		require.NoError(t, e.Stop(context.Background()))
+	})
+}
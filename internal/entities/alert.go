@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type AlertCondition string
+
+const (
+	AlertAbove         AlertCondition = "above"
+	AlertBelow         AlertCondition = "below"
+	AlertPercentChange AlertCondition = "percent_change"
+)
+
+// Alert fires a webhook when the price of Title in Quote matches Condition:
+// crosses Threshold upwards (above) or downwards (below), or moves by
+// Threshold percent from LastPrice (percent_change). It fires at most once per
+// Cooldown.
+type Alert struct {
+	ID         int64
+	Title      string
+	Quote      string
+	Condition  AlertCondition
+	Threshold  decimal.Decimal
+	Cooldown   time.Duration
+	WebhookURL string
+	// Secret signs the webhook body with HMAC-SHA256.
+	Secret string
+	// LastPrice is the last price above and below alerts were evaluated at.
+	// For percent_change it's the price the alert last fired at, or the first
+	// price it saw.
+	LastPrice   decimal.Decimal
+	LastFiredAt time.Time
+	CreateTime  time.Time
+}
+
+func NewAlert(title, quote string, condition AlertCondition, threshold decimal.Decimal, cooldown time.Duration, webhookURL, secret string) (*Alert, error) {
+	if title == "" {
+		return nil, errors.Wrap(ErrInvalidParams, "Title is empty")
+	}
+	if quote == "" {
+		return nil, errors.Wrap(ErrInvalidParams, "Quote is empty")
+	}
+	switch condition {
+	case AlertAbove, AlertBelow, AlertPercentChange:
+	default:
+		return nil, errors.Wrap(ErrInvalidParams, fmt.Sprintf("Unknown condition: %s", condition))
+	}
+	if !threshold.IsPositive() {
+		return nil, errors.Wrap(ErrInvalidParams, "Threshold must be positive")
+	}
+	if cooldown < 0 {
+		return nil, errors.Wrap(ErrInvalidParams, "Cooldown negative")
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Wrap(ErrInvalidParams, fmt.Sprintf("Invalid webhook url: %s", webhookURL))
+	}
+	if internalHost(u.Hostname()) {
+		return nil, errors.Wrap(ErrInvalidParams, fmt.Sprintf("Webhook url targets an internal host: %s", webhookURL))
+	}
+	return &Alert{
+		Title:      title,
+		Quote:      quote,
+		Condition:  condition,
+		Threshold:  threshold,
+		Cooldown:   cooldown,
+		WebhookURL: webhookURL,
+		Secret:     secret,
+	}, nil
+}
+
+// internalHost reports whether host is localhost or an InternalIP. A name
+// resolving to one is only caught when the webhook is delivered.
+func internalHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && InternalIP(ip)
+}
+
+// InternalIP reports whether ip is a loopback, private, link-local or
+// unspecified address, which webhooks must not reach.
+func InternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()
+}
+
+// Triggered reports whether price fires the alert at now. Above and below
+// alerts fire when price crosses Threshold since LastPrice, so a price that
+// stays past Threshold fires once; a zero LastPrice counts as a crossing.
+func (a *Alert) Triggered(price decimal.Decimal, now time.Time) bool {
+	if !a.LastFiredAt.IsZero() && now.Sub(a.LastFiredAt) < a.Cooldown {
+		return false
+	}
+	switch a.Condition {
+	case AlertAbove:
+		return price.GreaterThanOrEqual(a.Threshold) && (a.LastPrice.IsZero() || a.LastPrice.LessThan(a.Threshold))
+	case AlertBelow:
+		return price.LessThanOrEqual(a.Threshold) && (a.LastPrice.IsZero() || a.LastPrice.GreaterThan(a.Threshold))
+	case AlertPercentChange:
+		if a.LastPrice.IsZero() {
+			return false
+		}
+		change := price.Sub(a.LastPrice).Abs().Mul(decimal.NewFromInt(100))
+		// change / LastPrice >= Threshold без деления с округлением.
+		return change.GreaterThanOrEqual(a.Threshold.Mul(a.LastPrice.Abs()))
+	}
+	return false
+}
+
+// Delivery is one attempt to deliver an alert webhook. StatusCode is zero
+// when no response was received.
+type Delivery struct {
+	ID         int64
+	AlertID    int64
+	Attempt    int
+	StatusCode int
+	Error      string
+	CreateTime time.Time
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Candle is an OHLC summary of the prices of a coin in one quote currency
+// created in [Start, Start+Interval).
+type Candle struct {
+	Title    string
+	Quote    string
+	Start    time.Time
+	Interval time.Duration
+	Open     decimal.Decimal
+	High     decimal.Decimal
+	Low      decimal.Decimal
+	Close    decimal.Decimal
+}
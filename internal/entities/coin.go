@@ -4,20 +4,33 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 )
 
 type Coin struct {
-	Title      string
-	Price      float64
+	// ID identifies a stored price; it grows with every insert.
+	ID    int64
+	Title string
+	Quote string
+	// Price is exact: upstream prices are parsed from their decimal text.
+	Price      decimal.Decimal
 	CreateTime time.Time
+	// From is set only on aggregates: the aggregate covers prices created
+	// between From and CreateTime.
+	From time.Time
+	// Provider names the upstream that supplied the price.
+	Provider string
 }
 
-func NewCoin(title string, price float64, created time.Time) (*Coin, error) {
+func NewCoin(title, quote string, price decimal.Decimal, created time.Time) (*Coin, error) {
 	if title == "" {
 		return nil, errors.Wrap(ErrInvalidParams, "Title is empty")
 	}
-	if price < 0 {
+	if quote == "" {
+		return nil, errors.Wrap(ErrInvalidParams, "Quote is empty")
+	}
+	if price.IsNegative() {
 		return nil, errors.Wrap(ErrInvalidParams, "Price negative")
 	}
-	return &Coin{Title: title, Price: price, CreateTime: created}, nil
+	return &Coin{Title: title, Quote: quote, Price: price, CreateTime: created}, nil
 }
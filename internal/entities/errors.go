@@ -6,4 +6,6 @@ var (
 	ErrInvalidParams  = errors.New("Invalid Params")
 	ErrInternalServer = errors.New("Server Error")
 	ErrGetFunc        = errors.New("Func Error")
+	ErrNotFound       = errors.New("Not Found")
+	ErrConflict       = errors.New("Conflict")
 )
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// Status summarizes how fresh the stored prices are.
+type Status struct {
+	// LastFetch is the time of the last successful upstream fetch of this
+	// process, zero if there was none.
+	LastFetch time.Time
+	Symbols   []SymbolStatus
+	// Instance identifies this process and Leader tells whether it ingests
+	// prices. LeaderInstance is the instance that does, empty without leader
+	// election or while no instance holds the lock.
+	Instance       string
+	Leader         bool
+	LeaderInstance string
+}
+
+// SymbolStatus is the time the latest price of a pair was stored at. Stale
+// pairs weren't updated within the readiness staleness window.
+type SymbolStatus struct {
+	Title      string
+	Quote      string
+	UpdateTime time.Time
+	Stale      bool
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var symbolTitle = regexp.MustCompile(`^[A-Z0-9]{1,50}$`)
+
+// Symbol is a coin tracked by the scheduler. Paused symbols stay in the list
+// but their prices aren't fetched.
+type Symbol struct {
+	Title      string
+	Paused     bool
+	CreateTime time.Time
+}
+
+func NewSymbol(title string) (*Symbol, error) {
+	title = strings.ToUpper(strings.TrimSpace(title))
+	if !symbolTitle.MatchString(title) {
+		return nil, errors.Wrap(ErrInvalidParams, fmt.Sprintf("title: %q", title))
+	}
+	return &Symbol{Title: title}, nil
+}
@@ -0,0 +1,94 @@
+// Package lifecycle starts the parts of the application and stops them in
+// order on shutdown.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+// Component is a part of the application with a lifetime, e.g. a server.
+type Component struct {
+	Name string
+	// Run blocks while the component works; an error shuts the application
+	// down. Components without Run only need Stop.
+	Run func() error
+	// Stop makes Run return and releases the component, waiting for
+	// in-flight work until ctx is done.
+	Stop func(ctx context.Context) error
+}
+
+// Manager runs Components until shutdown.
+type Manager struct {
+	logger     *slog.Logger
+	timeout    time.Duration
+	components []Component
+}
+
+// NewManager returns a Manager that gives the components timeout to stop.
+func NewManager(logger *slog.Logger, timeout time.Duration) (*Manager, error) {
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	if timeout <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("timeout: %s", timeout))
+	}
+	return &Manager{logger: logger, timeout: timeout}, nil
+}
+
+// Add registers c. Components are started in the order they were added and
+// stopped in reverse, so a component may rely on the ones added before it.
+func (m *Manager) Add(c Component) {
+	m.components = append(m.components, c)
+}
+
+// Run starts every component and blocks until ctx is done or one of them
+// fails. Then it stops the components within the timeout and returns the
+// failure, or the first Stop error if nothing failed.
+func (m *Manager) Run(ctx context.Context) error {
+	failed := make(chan error, len(m.components))
+	for _, c := range m.components {
+		if c.Run == nil {
+			continue
+		}
+		go func(c Component) {
+			if err := c.Run(); err != nil {
+				failed <- errors.Wrap(err, fmt.Sprintf("%s failed", c.Name))
+			}
+		}(c)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+		m.logger.Info("shutting down")
+	case err = <-failed:
+		m.logger.Error("shutting down", slog.Any("error", err))
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		if c.Stop == nil {
+			continue
+		}
+		if stopErr := c.Stop(stopCtx); stopErr != nil {
+			m.logger.Error("stop failed", slog.String("component", c.Name), slog.Any("error", stopErr))
+			if err == nil {
+				err = errors.Wrap(stopErr, fmt.Sprintf("stop %s failed", c.Name))
+			}
+			continue
+		}
+		m.logger.Debug("stopped", slog.String("component", c.Name))
+	}
+
+	return err
+}
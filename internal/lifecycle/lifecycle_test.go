@@ -0,0 +1,165 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/lifecycle"
+	"currency/internal/logging"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder collects the order of component events.
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// server is a component whose Run blocks until Stop.
+func server(name string, r *recorder) lifecycle.Component {
+	done := make(chan struct{})
+	return lifecycle.Component{
+		Name: name,
+		Run: func() error {
+			<-done
+			r.add(name + " returned")
+			return nil
+		},
+		Stop: func(context.Context) error {
+			r.add(name + " stopped")
+			close(done)
+			return nil
+		},
+	}
+}
+
+func closer(name string, r *recorder) lifecycle.Component {
+	return lifecycle.Component{
+		Name: name,
+		Stop: func(context.Context) error {
+			r.add(name + " stopped")
+			return nil
+		},
+	}
+}
+
+func newManager(t *testing.T, timeout time.Duration) *lifecycle.Manager {
+	m, err := lifecycle.NewManager(logging.Nop(), timeout)
+	require.NoError(t, err)
+	return m
+}
+
+func TestNewManager(t *testing.T) {
+	_, err := lifecycle.NewManager(nil, time.Second)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+
+	_, err = lifecycle.NewManager(logging.Nop(), 0)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+}
+
+func TestManager_Run(t *testing.T) {
+	t.Run("stops in reverse order on cancel", func(t *testing.T) {
+		r := &recorder{}
+		m := newManager(t, time.Second)
+		m.Add(closer("storage", r))
+		m.Add(closer("service", r))
+		m.Add(server("http", r))
+		m.Add(server("grpc", r))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.NoError(t, m.Run(ctx))
+
+		assert.Eventually(t, func() bool { return len(r.get()) == 6 }, time.Second, time.Millisecond)
+		var stops []string
+		for _, e := range r.get() {
+			if e == "http returned" || e == "grpc returned" {
+				continue
+			}
+			stops = append(stops, e)
+		}
+		assert.Equal(t, []string{"grpc stopped", "http stopped", "service stopped", "storage stopped"}, stops)
+	})
+
+	t.Run("failed component shuts down the rest", func(t *testing.T) {
+		r := &recorder{}
+		m := newManager(t, time.Second)
+		m.Add(closer("storage", r))
+		m.Add(server("http", r))
+		m.Add(lifecycle.Component{
+			Name: "grpc",
+			Run:  func() error { return errors.New("address already in use") },
+			Stop: func(context.Context) error {
+				r.add("grpc stopped")
+				return nil
+			},
+		})
+
+		err := m.Run(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "grpc failed: address already in use")
+		assert.Subset(t, r.get(), []string{"grpc stopped", "http stopped", "storage stopped"})
+	})
+
+	t.Run("stop errors don't stop the rest", func(t *testing.T) {
+		r := &recorder{}
+		m := newManager(t, time.Second)
+		m.Add(closer("storage", r))
+		m.Add(lifecycle.Component{
+			Name: "scheduler",
+			Stop: func(context.Context) error { return errors.New("update is still running") },
+		})
+		m.Add(closer("http", r))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := m.Run(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "stop scheduler failed")
+		assert.Equal(t, []string{"http stopped", "storage stopped"}, r.get())
+	})
+
+	t.Run("components share the timeout", func(t *testing.T) {
+		m := newManager(t, 50*time.Millisecond)
+		var deadlines []time.Time
+		for _, name := range []string{"storage", "http"} {
+			m.Add(lifecycle.Component{
+				Name: name,
+				Stop: func(ctx context.Context) error {
+					deadline, ok := ctx.Deadline()
+					require.True(t, ok)
+					deadlines = append(deadlines, deadline)
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			})
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		start := time.Now()
+		err := m.Run(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+		require.Len(t, deadlines, 2)
+		assert.Equal(t, deadlines[0], deadlines[1])
+	})
+}
@@ -0,0 +1,145 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     logging.Config
+		wantErr bool
+	}{
+		{name: "defaults", cfg: logging.Config{}},
+		{name: "console debug", cfg: logging.Config{Level: "debug", Format: "console"}},
+		{name: "invalid level", cfg: logging.Config{Level: "verbose"}, wantErr: true},
+		{name: "invalid format", cfg: logging.Config{Format: "xml"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := logging.New(tt.cfg, &bytes.Buffer{})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entities.ErrInvalidParams)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{Level: "warn"}, &buf)
+	require.NoError(t, err)
+
+	logger.Info("skipped")
+	assert.Zero(t, buf.Len())
+
+	logger.Warn("logged")
+	assert.Equal(t, "logged", decode(t, &buf)["msg"])
+}
+
+func TestNew_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{}, &buf)
+	require.NoError(t, err)
+
+	logger.With(slog.String("component", "test")).InfoContext(logging.WithRequestID(context.Background(), "abc"), "hello")
+
+	record := decode(t, &buf)
+	assert.Equal(t, "abc", record["request_id"])
+	assert.Equal(t, "test", record["component"])
+}
+
+func TestNew_Redaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{}, &buf)
+	require.NoError(t, err)
+
+	logger.Info("connecting",
+		slog.String("conn", "postgres://postgres:12345go@db:5432/postgres?sslmode=disable"),
+		slog.String("secret", "s3cr3t"),
+		slog.String("webhook_token", "t0k3n"),
+	)
+
+	out := buf.String()
+	assert.NotContains(t, out, "12345go")
+	assert.NotContains(t, out, "s3cr3t")
+	assert.NotContains(t, out, "t0k3n")
+	assert.Contains(t, out, "postgres://postgres:xxxxx@db:5432/postgres?sslmode=disable")
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"postgres://user:pass@db:5432/currency", "postgres://user:xxxxx@db:5432/currency"},
+		{"postgres://user@db:5432/currency", "postgres://user@db:5432/currency"},
+		{"host=db user=user password=pass dbname=currency", "host=db user=user password=xxxxx dbname=currency"},
+		{"no secrets here", "no secrets here"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, logging.Redact(tt.in))
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{}, &buf)
+	require.NoError(t, err)
+
+	var got string
+	r := chi.NewRouter()
+	r.Use(logging.Middleware(logger))
+	r.Get("/v1/alerts/{id}", func(rw http.ResponseWriter, req *http.Request) {
+		got = logging.RequestID(req.Context())
+		rw.WriteHeader(http.StatusNotFound)
+	})
+
+	t.Run("client id", func(t *testing.T) {
+		buf.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/v1/alerts/1", nil)
+		req.Header.Set(logging.RequestIDHeader, "abc")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, "abc", got)
+		assert.Equal(t, "abc", rec.Header().Get(logging.RequestIDHeader))
+
+		record := decode(t, &buf)
+		assert.Equal(t, "http request", record["msg"])
+		assert.Equal(t, "abc", record["request_id"])
+		assert.Equal(t, "/v1/alerts/{id}", record["route"])
+		assert.Equal(t, float64(http.StatusNotFound), record["status"])
+	})
+
+	t.Run("generated id", func(t *testing.T) {
+		buf.Reset()
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/alerts/1", nil))
+
+		assert.Len(t, got, 16)
+		assert.Equal(t, got, rec.Header().Get(logging.RequestIDHeader))
+		assert.Equal(t, got, decode(t, &buf)["request_id"])
+	})
+}
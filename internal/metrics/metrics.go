@@ -0,0 +1,163 @@
+// Package metrics holds the Prometheus collectors of the service. They are
+// registered with the default registry served by Handler.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"currency/internal/adapters/client/resilience"
+	"currency/internal/entities"
+	"currency/internal/usecases"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "currency"
+
+var (
+	UpstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Duration of price requests to a provider, retries included.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider"})
+
+	UpstreamErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_errors_total",
+		Help:      "Failed price requests to a provider.",
+	}, []string{"provider"})
+
+	LastFetch = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_fetch_timestamp_seconds",
+		Help:      "Unix time of the last price fetched from any provider.",
+	}, []string{"title", "quote"})
+
+	CoinsStored = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "coins_stored_total",
+		Help:      "Price rows written to storage.",
+	}, []string{"title"})
+
+	RolledUp = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rolled_up_rows_total",
+		Help:      "Raw price rows and hourly rollups moved to a coarser tier by retention.",
+	}, []string{"tier"})
+
+	Leader = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "leader",
+		Help:      "1 while this instance is the leader that ingests prices, 0 otherwise.",
+	})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by route pattern, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+)
+
+var breakers = &breakerCollector{
+	desc: prometheus.NewDesc(namespace+"_breaker_state",
+		"Circuit breaker state of a provider: 0 closed, 1 open, 2 half-open.", []string{"provider"}, nil),
+}
+
+func init() {
+	prometheus.MustRegister(breakers)
+}
+
+// breakerCollector asks for the breaker states at every scrape: an open
+// breaker turns half-open without any call.
+type breakerCollector struct {
+	desc   *prometheus.Desc
+	mu     sync.RWMutex
+	states func() map[string]resilience.State
+}
+
+func (c *breakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *breakerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	states := c.states
+	c.mu.RUnlock()
+	if states == nil {
+		return
+	}
+	for provider, state := range states() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(state), provider)
+	}
+}
+
+// SetBreakers reports the provider breaker states returned by states as
+// currency_breaker_state.
+func SetBreakers(states func() map[string]resilience.State) {
+	breakers.mu.Lock()
+	defer breakers.mu.Unlock()
+	breakers.states = states
+}
+
+// Handler serves the default registry.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+type client struct {
+	provider string
+	next     usecases.Client
+}
+
+// InstrumentClient records the latency, errors and fetched symbols of the
+// provider client next.
+func InstrumentClient(provider string, next usecases.Client) usecases.Client {
+	return &client{provider: provider, next: next}
+}
+
+func (c *client) GetCoins(ctx context.Context, titles []string, quotes []string) ([]entities.Coin, error) {
+	start := time.Now()
+	coins, err := c.next.GetCoins(ctx, titles, quotes)
+	UpstreamDuration.WithLabelValues(c.provider).Observe(time.Since(start).Seconds())
+	if err != nil {
+		UpstreamErrors.WithLabelValues(c.provider).Inc()
+		return nil, err
+	}
+
+	now := float64(time.Now().Unix())
+	for _, coin := range coins {
+		LastFetch.WithLabelValues(coin.Title, coin.Quote).Set(now)
+	}
+	return coins, nil
+}
+
+// Middleware observes HTTPDuration. It must be installed with chi's Use so
+// the route pattern is known once the request is served; unmatched requests
+// are labelled with an empty route.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(rw, req.ProtoMajor)
+		next.ServeHTTP(ww, req)
+
+		var route string
+		if rctx := chi.RouteContext(req.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+		code := ww.Status()
+		if code == 0 {
+			code = http.StatusOK
+		}
+		HTTPDuration.WithLabelValues(route, req.Method, strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+	})
+}
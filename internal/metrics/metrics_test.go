@@ -0,0 +1,88 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"currency/internal/adapters/client/resilience"
+	"currency/internal/entities"
+	"currency/internal/metrics"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mock.NewMockClient(ctrl)
+	gomock.InOrder(
+		next.EXPECT().GetCoins(gomock.Any(), []string{"BTC"}, nil).
+			Return([]entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1)}}, nil),
+		next.EXPECT().GetCoins(gomock.Any(), []string{"BTC"}, nil).
+			Return(nil, errors.New("upstream failed")),
+	)
+	c := metrics.InstrumentClient("test-provider", next)
+
+	before := time.Now().Unix()
+	_, err := c.GetCoins(context.Background(), []string{"BTC"}, nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, testutil.ToFloat64(metrics.LastFetch.WithLabelValues("BTC", "USD")), float64(before))
+	assert.Zero(t, testutil.ToFloat64(metrics.UpstreamErrors.WithLabelValues("test-provider")))
+
+	_, err = c.GetCoins(context.Background(), []string{"BTC"}, nil)
+	require.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.UpstreamErrors.WithLabelValues("test-provider")))
+
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.UpstreamDuration, "currency_upstream_request_duration_seconds"))
+}
+
+func TestSetBreakers(t *testing.T) {
+	states := map[string]resilience.State{"kraken": resilience.Closed, "coinbase": resilience.Open}
+	metrics.SetBreakers(func() map[string]resilience.State { return states })
+	defer metrics.SetBreakers(nil)
+
+	want := `
+# HELP currency_breaker_state Circuit breaker state of a provider: 0 closed, 1 open, 2 half-open.
+# TYPE currency_breaker_state gauge
+currency_breaker_state{provider="coinbase"} 1
+currency_breaker_state{provider="kraken"} 0
+`
+	require.NoError(t, testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(want), "currency_breaker_state"))
+
+	states["kraken"] = resilience.HalfOpen
+	want = strings.Replace(want, `{provider="kraken"} 0`, `{provider="kraken"} 2`, 1)
+	require.NoError(t, testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(want), "currency_breaker_state"))
+}
+
+func TestMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(metrics.Middleware)
+	r.Get("/test/{id}", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})
+	r.Handle("/metrics", metrics.Handler())
+
+	for _, id := range []string{"1", "2"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test/"+id, nil))
+		require.Equal(t, http.StatusTeapot, rec.Code)
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `currency_http_request_duration_seconds_count{code="418",method="GET",route="/test/{id}"} 2`)
+}
@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	entities "currency/internal/entities"
+	usecases "currency/internal/usecases"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// FetchMissing mocks base method.
+func (m *MockService) FetchMissing(ctx context.Context, titles, quotes []string) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMissing", ctx, titles, quotes)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMissing indicates an expected call of FetchMissing.
+func (mr *MockServiceMockRecorder) FetchMissing(ctx, titles, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMissing", reflect.TypeOf((*MockService)(nil).FetchMissing), ctx, titles, quotes)
+}
+
+// GetAvgPrice mocks base method.
+func (m *MockService) GetAvgPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAvgPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvgPrice indicates an expected call of GetAvgPrice.
+func (mr *MockServiceMockRecorder) GetAvgPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvgPrice", reflect.TypeOf((*MockService)(nil).GetAvgPrice), varargs...)
+}
+
+// GetCoinsFromAPI mocks base method.
+func (m *MockService) GetCoinsFromAPI(ctx context.Context, titles, quotes []string) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinsFromAPI", ctx, titles, quotes)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinsFromAPI indicates an expected call of GetCoinsFromAPI.
+func (mr *MockServiceMockRecorder) GetCoinsFromAPI(ctx, titles, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsFromAPI", reflect.TypeOf((*MockService)(nil).GetCoinsFromAPI), ctx, titles, quotes)
+}
+
+// GetLastPrice mocks base method.
+func (m *MockService) GetLastPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLastPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastPrice indicates an expected call of GetLastPrice.
+func (mr *MockServiceMockRecorder) GetLastPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPrice", reflect.TypeOf((*MockService)(nil).GetLastPrice), varargs...)
+}
+
+// GetMaxPrice mocks base method.
+func (m *MockService) GetMaxPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMaxPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaxPrice indicates an expected call of GetMaxPrice.
+func (mr *MockServiceMockRecorder) GetMaxPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxPrice", reflect.TypeOf((*MockService)(nil).GetMaxPrice), varargs...)
+}
+
+// GetMinPrice mocks base method.
+func (m *MockService) GetMinPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMinPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMinPrice indicates an expected call of GetMinPrice.
+func (mr *MockServiceMockRecorder) GetMinPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinPrice", reflect.TypeOf((*MockService)(nil).GetMinPrice), varargs...)
+}
+
+// Subscribe mocks base method.
+func (m *MockService) Subscribe(titles, quotes []string) *usecases.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", titles, quotes)
+	ret0, _ := ret[0].(*usecases.Subscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockServiceMockRecorder) Subscribe(titles, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockService)(nil).Subscribe), titles, quotes)
+}
@@ -0,0 +1,239 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/usecases"
+	currencyv1 "currency/pkg/api/currency/v1"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server is the gRPC counterpart of the HTTP public server.
+type Server struct {
+	currencyv1.UnimplementedCurrencyServiceServer
+
+	port    string
+	grpc    *grpc.Server
+	service Service
+	// closing is closed on Shutdown to end StreamPrices calls, which
+	// GracefulStop would wait for.
+	closing chan struct{}
+	once    sync.Once
+}
+
+func NewServer(service Service, port string, logger *slog.Logger) (*Server, error) {
+	if service == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "service is nil")
+	}
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLogger(logger)),
+		grpc.ChainStreamInterceptor(streamLogger(logger)),
+	)
+	s := &Server{port: port, grpc: server, service: service, closing: make(chan struct{})}
+	currencyv1.RegisterCurrencyServiceServer(s.grpc, s)
+
+	return s, nil
+}
+
+func (s *Server) Run() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", s.port))
+	if err != nil {
+		return errors.Wrap(entities.ErrInternalServer, err.Error())
+	}
+	return s.Serve(lis)
+}
+
+// Serve accepts connections on lis until Stop.
+func (s *Server) Serve(lis net.Listener) error {
+	if err := s.grpc.Serve(lis); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, err.Error())
+	}
+	return nil
+}
+
+func (s *Server) Stop() {
+	s.grpc.Stop()
+}
+
+// Shutdown stops accepting calls, ends the streams and waits for the other
+// in-flight calls until ctx is done, then cancels them.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.once.Do(func() { close(s.closing) })
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return errors.Wrap(entities.ErrInternalServer, ctx.Err().Error())
+	}
+}
+
+func (s *Server) GetLastPrice(ctx context.Context, req *currencyv1.GetPriceRequest) (*currencyv1.GetPriceResponse, error) {
+	return s.getPrices(ctx, req, s.service.GetLastPrice, false)
+}
+
+func (s *Server) GetMaxPrice(ctx context.Context, req *currencyv1.GetPriceRequest) (*currencyv1.GetPriceResponse, error) {
+	return s.getPrices(ctx, req, s.service.GetMaxPrice, true)
+}
+
+func (s *Server) GetMinPrice(ctx context.Context, req *currencyv1.GetPriceRequest) (*currencyv1.GetPriceResponse, error) {
+	return s.getPrices(ctx, req, s.service.GetMinPrice, true)
+}
+
+func (s *Server) GetAvgPrice(ctx context.Context, req *currencyv1.GetPriceRequest) (*currencyv1.GetPriceResponse, error) {
+	return s.getPrices(ctx, req, s.service.GetAvgPrice, true)
+}
+
+// StreamPrices sends the coins of a Subscription until the client goes away.
+func (s *Server) StreamPrices(req *currencyv1.StreamPricesRequest, stream currencyv1.CurrencyService_StreamPricesServer) error {
+	sub := s.service.Subscribe(upper(req.GetFsyms()), upper(req.GetTsyms()))
+	defer sub.Close()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.closing:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-sub.Ready():
+			coins := sub.Next()
+			if len(coins) == 0 {
+				continue
+			}
+			if err := stream.Send(&currencyv1.StreamPricesResponse{Coins: toCoinsPB(coins)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// priceFunc is the shape shared by every Service price query.
+type priceFunc func(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+
+// getPrices follows the HTTP handlePrices pipeline: titles missing from
+// storage are fetched from the external API once and the query is repeated.
+func (s *Server) getPrices(ctx context.Context, req *currencyv1.GetPriceRequest, get priceFunc, withRange bool) (*currencyv1.GetPriceResponse, error) {
+	titles := upper(req.GetFsyms())
+	if len(titles) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "fsyms is empty")
+	}
+	for _, t := range titles {
+		if t == "" {
+			return nil, status.Error(codes.InvalidArgument, "fsyms contains an empty title")
+		}
+	}
+	quotes := upper(req.GetTsyms())
+
+	var opts []usecases.Option
+	if withRange {
+		var err error
+		if opts, err = parseRange(req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	coins, err := get(ctx, titles, quotes, opts...)
+	if errors.Is(err, entities.ErrNotFound) {
+		// Из API берем только никогда не сохраненные titles, чтобы чтение не писало в БД.
+		if _, err := s.service.FetchMissing(ctx, titles, quotes); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		coins, err = get(ctx, titles, quotes, opts...)
+	}
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, entities.ErrInvalidParams) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(coins) == 0 {
+		return nil, status.Error(codes.NotFound, "no coins found")
+	}
+
+	return &currencyv1.GetPriceResponse{Coins: toCoinsPB(coins)}, nil
+}
+
+func parseRange(req *currencyv1.GetPriceRequest) ([]usecases.Option, error) {
+	if req.Window != nil {
+		if req.From != nil || req.To != nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, "window can't be combined with from/to")
+		}
+		d := req.Window.AsDuration()
+		if d <= 0 {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("window: %s", d))
+		}
+		return []usecases.Option{usecases.WithWindow(d)}, nil
+	}
+
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "from is after to")
+	}
+	if from.IsZero() && to.IsZero() {
+		return nil, nil
+	}
+	return []usecases.Option{usecases.WithRange(from, to)}, nil
+}
+
+func upper(symbols []string) []string {
+	if len(symbols) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		res = append(res, strings.ToUpper(s))
+	}
+	return res
+}
+
+func toCoinsPB(coins []entities.Coin) []*currencyv1.Coin {
+	res := make([]*currencyv1.Coin, 0, len(coins))
+	for _, coin := range coins {
+		c := &currencyv1.Coin{
+			Title:        coin.Title,
+			Quote:        coin.Quote,
+			Price:        coin.Price.InexactFloat64(),
+			PriceDecimal: coin.Price.String(),
+			CreateTime:   timestamppb.New(coin.CreateTime),
+			Provider:     coin.Provider,
+		}
+		if !coin.From.IsZero() {
+			c.From = timestamppb.New(coin.From)
+		}
+		res = append(res, c)
+	}
+	return res
+}
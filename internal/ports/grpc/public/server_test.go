@@ -0,0 +1,294 @@
+package public_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/ports/grpc/public"
+	mock "currency/internal/ports/grpc/public/mocks"
+	"currency/internal/usecases"
+	usecasesmock "currency/internal/usecases/mocks"
+	currencyv1 "currency/pkg/api/currency/v1"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	from = time.Date(2025, 5, 16, 12, 0, 0, 0, time.UTC)
+	to   = time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+)
+
+// newClient serves service over an in-memory listener.
+func newClient(t *testing.T, service public.Service) currencyv1.CurrencyServiceClient {
+	_, client := serve(t, service)
+	return client
+}
+
+func serve(t *testing.T, service public.Service) (*public.Server, currencyv1.CurrencyServiceClient) {
+	server, err := public.NewServer(service, "9090", logging.Nop())
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return server, currencyv1.NewCurrencyServiceClient(conn)
+}
+
+func TestServer_GetPrice(t *testing.T) {
+	aggregate := []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.RequireFromString("103512.456"), CreateTime: to, From: from}}
+
+	tests := []struct {
+		name     string
+		req      *currencyv1.GetPriceRequest
+		call     func(c currencyv1.CurrencyServiceClient, ctx context.Context, req *currencyv1.GetPriceRequest, opts ...grpc.CallOption) (*currencyv1.GetPriceResponse, error)
+		prepare  func(s *mock.MockService)
+		wantCode codes.Code
+		want     []*currencyv1.Coin
+	}{
+		{
+			name: "last",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"btc"}, Tsyms: []string{"usd"}},
+			call: currencyv1.CurrencyServiceClient.GetLastPrice,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetLastPrice(gomock.Any(), []string{"BTC"}, []string{"USD"}).
+					Return([]entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.RequireFromString("103512.4"), CreateTime: to, Provider: "binance"}}, nil)
+			},
+			want: []*currencyv1.Coin{{Title: "BTC", Quote: "USD", Price: 103512.4, PriceDecimal: "103512.4", CreateTime: timestamppb.New(to), Provider: "binance"}},
+		},
+		{
+			name: "max with range",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}, From: timestamppb.New(from), To: timestamppb.New(to)},
+			call: currencyv1.CurrencyServiceClient.GetMaxPrice,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetMaxPrice(gomock.Any(), []string{"BTC"}, nil, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ []string, opts ...usecases.Option) ([]entities.Coin, error) {
+						o := &usecases.Options{}
+						for _, opt := range opts {
+							opt(o)
+						}
+						assert.Equal(t, from, o.From)
+						assert.Equal(t, to, o.To)
+						return aggregate, nil
+					})
+			},
+			want: []*currencyv1.Coin{{Title: "BTC", Quote: "USD", Price: 103512.456, PriceDecimal: "103512.456", CreateTime: timestamppb.New(to), From: timestamppb.New(from)}},
+		},
+		{
+			name: "min with window",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}, Window: durationpb.New(24 * time.Hour)},
+			call: currencyv1.CurrencyServiceClient.GetMinPrice,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetMinPrice(gomock.Any(), []string{"BTC"}, nil, gomock.Any()).Return(aggregate, nil)
+			},
+			want: []*currencyv1.Coin{{Title: "BTC", Quote: "USD", Price: 103512.456, PriceDecimal: "103512.456", CreateTime: timestamppb.New(to), From: timestamppb.New(from)}},
+		},
+		{
+			name: "avg fetches missing titles",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}},
+			call: currencyv1.CurrencyServiceClient.GetAvgPrice,
+			prepare: func(s *mock.MockService) {
+				gomock.InOrder(
+					s.EXPECT().GetAvgPrice(gomock.Any(), []string{"BTC"}, nil).Return(nil, entities.ErrNotFound),
+					s.EXPECT().FetchMissing(gomock.Any(), []string{"BTC"}, nil).Return(nil, nil),
+					s.EXPECT().GetAvgPrice(gomock.Any(), []string{"BTC"}, nil).Return(aggregate, nil),
+				)
+			},
+			want: []*currencyv1.Coin{{Title: "BTC", Quote: "USD", Price: 103512.456, PriceDecimal: "103512.456", CreateTime: timestamppb.New(to), From: timestamppb.New(from)}},
+		},
+		{
+			name:     "empty fsyms",
+			req:      &currencyv1.GetPriceRequest{},
+			call:     currencyv1.CurrencyServiceClient.GetLastPrice,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "window with from",
+			req:      &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}, From: timestamppb.New(from), Window: durationpb.New(time.Hour)},
+			call:     currencyv1.CurrencyServiceClient.GetMaxPrice,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "from after to",
+			req:      &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}, From: timestamppb.New(to), To: timestamppb.New(from)},
+			call:     currencyv1.CurrencyServiceClient.GetMaxPrice,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"XYZ"}},
+			call: currencyv1.CurrencyServiceClient.GetLastPrice,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetLastPrice(gomock.Any(), []string{"XYZ"}, nil).Return(nil, entities.ErrNotFound).Times(2)
+				s.EXPECT().FetchMissing(gomock.Any(), []string{"XYZ"}, nil).Return(nil, nil)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "empty range",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}, From: timestamppb.New(from), To: timestamppb.New(to)},
+			call: currencyv1.CurrencyServiceClient.GetMaxPrice,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetMaxPrice(gomock.Any(), []string{"BTC"}, nil, gomock.Any()).Return(nil, nil)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "invalid params aren't fetched",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}},
+			call: currencyv1.CurrencyServiceClient.GetLastPrice,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetLastPrice(gomock.Any(), []string{"BTC"}, nil).Return(nil, errors.Wrap(entities.ErrInvalidParams, "incorrect parameters"))
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "internal error",
+			req:  &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}},
+			call: currencyv1.CurrencyServiceClient.GetLastPrice,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetLastPrice(gomock.Any(), []string{"BTC"}, nil).Return(nil, errors.Wrap(entities.ErrGetFunc, "GetLastPrice"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mock.NewMockService(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(service)
+			}
+
+			resp, err := tt.call(newClient(t, service), context.Background(), tt.req)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Coins, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].String(), resp.Coins[i].String())
+			}
+		})
+	}
+}
+
+func TestServer_StreamPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage, client := usecasesmock.NewMockStorage(ctrl), usecasesmock.NewMockClient(ctrl)
+	service, err := usecases.NewService(storage, client, logging.Nop())
+	require.NoError(t, err)
+
+	port := mock.NewMockService(ctrl)
+	subscribed := make(chan struct{})
+	port.EXPECT().Subscribe([]string{"BTC"}, []string{"USD"}).DoAndReturn(func(titles, quotes []string) *usecases.Subscription {
+		defer close(subscribed)
+		return service.Subscribe(titles, quotes)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := newClient(t, port).StreamPrices(ctx, &currencyv1.StreamPricesRequest{Fsyms: []string{"btc"}, Tsyms: []string{"usd"}})
+	require.NoError(t, err)
+
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		t.Fatal("not subscribed")
+	}
+
+	coins := []entities.Coin{
+		{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(100), CreateTime: to},
+		{Title: "BTC", Quote: "EUR", Price: decimal.NewFromInt(90), CreateTime: to},
+		{Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(10), CreateTime: to},
+	}
+	client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+	storage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+	_, err = service.GetCoinsFromAPI(context.Background(), []string{"BTC", "ETH"}, nil)
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Coins, 1)
+	assert.Equal(t, "BTC", resp.Coins[0].Title)
+	assert.Equal(t, "USD", resp.Coins[0].Quote)
+	assert.Equal(t, 100.0, resp.Coins[0].Price)
+}
+
+func TestServer_RequestID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock.NewMockService(ctrl)
+	service.EXPECT().GetLastPrice(gomock.Any(), []string{"BTC"}, nil).
+		DoAndReturn(func(ctx context.Context, _, _ []string, _ ...usecases.Option) ([]entities.Coin, error) {
+			assert.Equal(t, "abc", logging.RequestID(ctx))
+			return []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1), CreateTime: to}}, nil
+		})
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "abc")
+	_, err := newClient(t, service).GetLastPrice(ctx, &currencyv1.GetPriceRequest{Fsyms: []string{"BTC"}}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc"}, header.Get("x-request-id"))
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err := usecases.NewService(usecasesmock.NewMockStorage(ctrl), usecasesmock.NewMockClient(ctrl), logging.Nop())
+	require.NoError(t, err)
+	port := mock.NewMockService(ctrl)
+	subscribed := make(chan struct{})
+	port.EXPECT().Subscribe(nil, nil).DoAndReturn(func(titles, quotes []string) *usecases.Subscription {
+		defer close(subscribed)
+		return service.Subscribe(titles, quotes)
+	})
+
+	server, client := serve(t, port)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamPrices(ctx, &currencyv1.StreamPricesRequest{})
+	require.NoError(t, err)
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		t.Fatal("not subscribed")
+	}
+
+	require.NoError(t, server.Shutdown(ctx))
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,19 @@
+package public
+
+import (
+	"context"
+
+	"currency/internal/entities"
+	"currency/internal/usecases"
+)
+
+//go:generate mockgen -source=service.go -destination=./mocks/service_mock.go -package=mock
+type Service interface {
+	GetLastPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetMinPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetMaxPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetAvgPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetCoinsFromAPI(ctx context.Context, titles, quotes []string) ([]entities.Coin, error)
+	FetchMissing(ctx context.Context, titles, quotes []string) ([]entities.Coin, error)
+	Subscribe(titles, quotes []string) *usecases.Subscription
+}
@@ -0,0 +1,258 @@
+package public
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/scheduler"
+	"currency/pkg/dto"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+)
+
+// SetAdminToken enables the admin API for requests bearing token. The admin
+// API is disabled while the token is empty.
+func (s *Server) SetAdminToken(token string) {
+	s.adminToken = token
+}
+
+// SetScheduler enables the scheduler endpoints of the admin API.
+func (s *Server) SetScheduler(scheduler Scheduler) {
+	s.scheduler = scheduler
+}
+
+// adminAuth lets through the requests with the admin bearer token.
+func (s *Server) adminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if s.adminToken == "" {
+			http.Error(rw, "admin API is disabled", http.StatusForbidden)
+			return
+		}
+
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			rw.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(rw, "invalid admin token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(rw, req)
+	})
+}
+
+func toSymbolDTO(symbol entities.Symbol) dto.SymbolDTO {
+	return dto.SymbolDTO{
+		Title:      symbol.Title,
+		Paused:     symbol.Paused,
+		CreateTime: symbol.CreateTime.Format(time.RFC3339),
+	}
+}
+
+// GetSymbolsHandler godoc
+//
+//	@Summary		List tracked symbols
+//	@Description	List the symbols whose prices are fetched by the scheduler, paused ones included
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{array}		dto.SymbolDTO
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/admin/symbols [get]
+func (s *Server) GetSymbolsHandler(rw http.ResponseWriter, req *http.Request) {
+	symbols, err := s.service.GetSymbols(req.Context())
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+
+	symbolsDTO := make(dto.SymbolsDTO, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbolsDTO = append(symbolsDTO, toSymbolDTO(symbol))
+	}
+	writeJSON(rw, http.StatusOK, symbolsDTO)
+}
+
+// AddSymbolHandler godoc
+//
+//	@Summary		Track symbol
+//	@Description	Start fetching the prices of a symbol on the next scheduled update
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		AdminToken
+//	@Param			symbol	body		dto.SymbolRequestDTO	true	"Symbol"
+//	@Success		201		{object}	dto.SymbolDTO
+//	@Failure		400		{object}	map[string]interface{}	"Invalid input"
+//	@Failure		401		{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403		{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		409		{object}	map[string]interface{}	"Symbol is already tracked"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/admin/symbols [post]
+func (s *Server) AddSymbolHandler(rw http.ResponseWriter, req *http.Request) {
+	var body dto.SymbolRequestDTO
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(rw, errors.Wrap(entities.ErrInvalidParams, "invalid body").Error(), http.StatusBadRequest)
+		return
+	}
+
+	symbol, err := s.service.AddSymbol(req.Context(), body.Title)
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+	writeJSON(rw, http.StatusCreated, toSymbolDTO(*symbol))
+}
+
+// PauseSymbolHandler godoc
+//
+//	@Summary		Pause or resume symbol
+//	@Description	Paused symbols stay tracked but their prices aren't fetched
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		AdminToken
+//	@Param			title	path		string				true	"Symbol title"
+//	@Param			pause	body		dto.SymbolPauseDTO	true	"Pause state"
+//	@Success		200		{object}	dto.SymbolDTO
+//	@Failure		400		{object}	map[string]interface{}	"Invalid input"
+//	@Failure		401		{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403		{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		404		{object}	map[string]interface{}	"Symbol is not tracked"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/admin/symbols/{title} [patch]
+func (s *Server) PauseSymbolHandler(rw http.ResponseWriter, req *http.Request) {
+	var body dto.SymbolPauseDTO
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(rw, errors.Wrap(entities.ErrInvalidParams, "invalid body").Error(), http.StatusBadRequest)
+		return
+	}
+
+	symbol, err := s.service.SetSymbolPaused(req.Context(), chi.URLParam(req, "title"), body.Paused)
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+	writeJSON(rw, http.StatusOK, toSymbolDTO(*symbol))
+}
+
+// DeleteSymbolHandler godoc
+//
+//	@Summary		Untrack symbol
+//	@Description	Stop fetching the prices of a symbol. Its stored prices are kept
+//	@Tags			admin
+//	@Security		AdminToken
+//	@Param			title	path	string	true	"Symbol title"
+//	@Success		204
+//	@Failure		400	{object}	map[string]interface{}	"Invalid input"
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		404	{object}	map[string]interface{}	"Symbol is not tracked"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/admin/symbols/{title} [delete]
+func (s *Server) DeleteSymbolHandler(rw http.ResponseWriter, req *http.Request) {
+	if err := s.service.DeleteSymbol(req.Context(), chi.URLParam(req, "title")); err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// withScheduler answers 503 while no scheduler is set.
+func (s *Server) withScheduler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if s.scheduler == nil {
+			http.Error(rw, "scheduler is not running", http.StatusServiceUnavailable)
+			return
+		}
+		handler(rw, req)
+	}
+}
+
+func toSchedulerDTO(status scheduler.Status) dto.SchedulerDTO {
+	schedulerDTO := dto.SchedulerDTO{Paused: status.Paused, Jobs: make([]dto.SchedulerJobDTO, 0, len(status.Jobs))}
+	for _, job := range status.Jobs {
+		jobDTO := dto.SchedulerJobDTO{Symbols: job.Symbols, Schedule: job.Schedule}
+		if !job.Next.IsZero() {
+			jobDTO.NextRun = job.Next.UTC().Format(time.RFC3339)
+		}
+		schedulerDTO.Jobs = append(schedulerDTO.Jobs, jobDTO)
+	}
+	return schedulerDTO
+}
+
+// GetSchedulerHandler godoc
+//
+//	@Summary		Get scheduler
+//	@Description	Get whether the scheduled updates are paused and the next run of every schedule
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	dto.SchedulerDTO
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		503	{object}	map[string]interface{}	"Scheduler is not running"
+//	@Router			/v1/admin/scheduler [get]
+func (s *Server) GetSchedulerHandler(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, toSchedulerDTO(s.scheduler.Status()))
+}
+
+// RefreshHandler godoc
+//
+//	@Summary		Refresh prices
+//	@Description	Fetch the prices of every tracked symbol now, even while the scheduler is paused
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{array}		dto.CoinDTO
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503	{object}	map[string]interface{}	"Scheduler is not running"
+//	@Router			/v1/admin/scheduler/refresh [post]
+func (s *Server) RefreshHandler(rw http.ResponseWriter, req *http.Request) {
+	coins, err := s.scheduler.Refresh(req.Context())
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+	writeJSON(rw, http.StatusOK, toCoinsDTO(coins))
+}
+
+// PauseSchedulerHandler godoc
+//
+//	@Summary		Pause scheduler
+//	@Description	Skip the scheduled updates of this instance until resumed
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	dto.SchedulerDTO
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		503	{object}	map[string]interface{}	"Scheduler is not running"
+//	@Router			/v1/admin/scheduler/pause [post]
+func (s *Server) PauseSchedulerHandler(rw http.ResponseWriter, _ *http.Request) {
+	s.scheduler.Pause()
+	writeJSON(rw, http.StatusOK, toSchedulerDTO(s.scheduler.Status()))
+}
+
+// ResumeSchedulerHandler godoc
+//
+//	@Summary		Resume scheduler
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	dto.SchedulerDTO
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		503	{object}	map[string]interface{}	"Scheduler is not running"
+//	@Router			/v1/admin/scheduler/resume [post]
+func (s *Server) ResumeSchedulerHandler(rw http.ResponseWriter, _ *http.Request) {
+	s.scheduler.Resume()
+	writeJSON(rw, http.StatusOK, toSchedulerDTO(s.scheduler.Status()))
+}
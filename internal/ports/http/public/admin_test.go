@@ -0,0 +1,271 @@
+package public_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"currency/internal/entities"
+	mock "currency/internal/ports/http/public/mocks"
+	"currency/internal/scheduler"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const adminToken = "admin-token"
+
+func TestServer_AdminAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "disabled", authorization: "Bearer ", wantStatus: http.StatusForbidden},
+		{name: "no header", token: adminToken, wantStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", token: adminToken, authorization: "Basic " + adminToken, wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", token: adminToken, authorization: "Bearer admin", wantStatus: http.StatusUnauthorized},
+		{name: "valid token", token: adminToken, authorization: "Bearer " + adminToken, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, service := newServer(t)
+			server.SetAdminToken(tt.token)
+			if tt.wantStatus == http.StatusOK {
+				service.EXPECT().GetSymbols(gomock.Any()).Return(nil, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/symbols", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestServer_SymbolHandlers(t *testing.T) {
+	btc := &entities.Symbol{Title: "BTC", CreateTime: from}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		prepare    func(s *mock.MockService)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			target: "/v1/admin/symbols",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetSymbols(gomock.Any()).Return([]entities.Symbol{*btc, {Title: "ETH", Paused: true, CreateTime: to}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"title":"BTC","paused":false,"create_time":"2025-05-16T12:00:00Z"},
+				{"title":"ETH","paused":true,"create_time":"2025-05-17T12:00:00Z"}]`,
+		},
+		{
+			name:   "add",
+			method: http.MethodPost,
+			target: "/v1/admin/symbols",
+			body:   `{"title":"btc"}`,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().AddSymbol(gomock.Any(), "btc").Return(btc, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"title":"BTC","paused":false,"create_time":"2025-05-16T12:00:00Z"}`,
+		},
+		{
+			name:       "add invalid body",
+			method:     http.MethodPost,
+			target:     "/v1/admin/symbols",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "add invalid title",
+			method: http.MethodPost,
+			target: "/v1/admin/symbols",
+			body:   `{"title":"BTC/USD"}`,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().AddSymbol(gomock.Any(), "BTC/USD").Return(nil, errors.Wrap(entities.ErrInvalidParams, "title"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "add tracked",
+			method: http.MethodPost,
+			target: "/v1/admin/symbols",
+			body:   `{"title":"BTC"}`,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().AddSymbol(gomock.Any(), "BTC").Return(nil, errors.Wrap(entities.ErrConflict, "Symbol is already tracked: BTC"))
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "pause",
+			method: http.MethodPatch,
+			target: "/v1/admin/symbols/BTC",
+			body:   `{"paused":true}`,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().SetSymbolPaused(gomock.Any(), "BTC", true).Return(&entities.Symbol{Title: "BTC", Paused: true, CreateTime: from}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"title":"BTC","paused":true,"create_time":"2025-05-16T12:00:00Z"}`,
+		},
+		{
+			name:   "pause not found",
+			method: http.MethodPatch,
+			target: "/v1/admin/symbols/XRP",
+			body:   `{"paused":false}`,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().SetSymbolPaused(gomock.Any(), "XRP", false).Return(nil, errors.Wrap(entities.ErrNotFound, "Unable to update symbol: XRP"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "delete",
+			method: http.MethodDelete,
+			target: "/v1/admin/symbols/BTC",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().DeleteSymbol(gomock.Any(), "BTC").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "storage error",
+			method: http.MethodGet,
+			target: "/v1/admin/symbols",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetSymbols(gomock.Any()).Return(nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, service := newServer(t)
+			server.SetAdminToken(adminToken)
+			if tt.prepare != nil {
+				tt.prepare(service)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_SchedulerHandlers(t *testing.T) {
+	status := scheduler.Status{Jobs: []scheduler.Job{
+		{Symbols: []string{"BTC", "ETH"}, Schedule: "10s", Next: to},
+		{Schedule: "1m"},
+	}}
+	paused := status
+	paused.Paused = true
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		prepare    func(s *mock.MockScheduler)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "status",
+			method: http.MethodGet,
+			target: "/v1/admin/scheduler",
+			prepare: func(s *mock.MockScheduler) {
+				s.EXPECT().Status().Return(status)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"paused":false,"jobs":[{"symbols":["BTC","ETH"],"schedule":"10s","next_run":"2025-05-17T12:00:00Z"},
+				{"schedule":"1m"}]}`,
+		},
+		{
+			name:   "pause",
+			method: http.MethodPost,
+			target: "/v1/admin/scheduler/pause",
+			prepare: func(s *mock.MockScheduler) {
+				gomock.InOrder(s.EXPECT().Pause(), s.EXPECT().Status().Return(paused))
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "resume",
+			method: http.MethodPost,
+			target: "/v1/admin/scheduler/resume",
+			prepare: func(s *mock.MockScheduler) {
+				gomock.InOrder(s.EXPECT().Resume(), s.EXPECT().Status().Return(status))
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "refresh",
+			method: http.MethodPost,
+			target: "/v1/admin/scheduler/refresh",
+			prepare: func(s *mock.MockScheduler) {
+				s.EXPECT().Refresh(gomock.Any()).Return([]entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(100000), CreateTime: to}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "refresh failed",
+			method: http.MethodPost,
+			target: "/v1/admin/scheduler/refresh",
+			prepare: func(s *mock.MockScheduler) {
+				s.EXPECT().Refresh(gomock.Any()).Return(nil, errors.Wrap(entities.ErrGetFunc, "GetCoinsFromAPI"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newServer(t)
+			server.SetAdminToken(adminToken)
+			sched := mock.NewMockScheduler(gomock.NewController(t))
+			tt.prepare(sched)
+			server.SetScheduler(sched)
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("no scheduler", func(t *testing.T) {
+		server, _ := newServer(t)
+		server.SetAdminToken(adminToken)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/scheduler/refresh", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
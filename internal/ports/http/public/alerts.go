@@ -0,0 +1,289 @@
+package public
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+	"currency/pkg/dto"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+)
+
+// errorStatus maps a Service alert or symbol error to a response status.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrInvalidParams):
+		return http.StatusBadRequest
+	case errors.Is(err, entities.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseAlertID(req *http.Request) (int64, error) {
+	param := chi.URLParam(req, "id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("id: %s", param))
+	}
+	return id, nil
+}
+
+// decodeAlert reads an AlertRequestDTO; the rule itself is validated by Service.
+func decodeAlert(req *http.Request) (entities.Alert, error) {
+	var body dto.AlertRequestDTO
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return entities.Alert{}, errors.Wrap(entities.ErrInvalidParams, "invalid body")
+	}
+
+	var cooldown time.Duration
+	if body.Cooldown != "" {
+		d, err := time.ParseDuration(body.Cooldown)
+		if err != nil || d < 0 {
+			return entities.Alert{}, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("cooldown: %s", body.Cooldown))
+		}
+		cooldown = d
+	}
+
+	return entities.Alert{
+		Title:      strings.ToUpper(body.Title),
+		Quote:      strings.ToUpper(body.Quote),
+		Condition:  entities.AlertCondition(body.Condition),
+		Threshold:  body.Threshold,
+		Cooldown:   cooldown,
+		WebhookURL: body.WebhookURL,
+		Secret:     body.Secret,
+	}, nil
+}
+
+// toAlertDTO leaves the secret out: it is only returned on creation.
+func toAlertDTO(alert entities.Alert) dto.AlertDTO {
+	alertDTO := dto.AlertDTO{
+		ID:         alert.ID,
+		Title:      alert.Title,
+		Quote:      alert.Quote,
+		Condition:  string(alert.Condition),
+		Threshold:  alert.Threshold,
+		Cooldown:   alert.Cooldown.String(),
+		WebhookURL: alert.WebhookURL,
+		CreateTime: alert.CreateTime.Format(time.RFC3339),
+	}
+	if !alert.LastPrice.IsZero() {
+		alertDTO.LastPrice = &alert.LastPrice
+	}
+	if !alert.LastFiredAt.IsZero() {
+		alertDTO.LastFiredAt = alert.LastFiredAt.Format(time.RFC3339)
+	}
+	return alertDTO
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// CreateAlertHandler godoc
+//
+//	@Summary		Create alert
+//	@Description	Create a price alert delivered as a webhook POST signed with HMAC-SHA256 of the body in the X-Signature-256 header. A secret is generated if none is given; it is returned only in this response
+//	@Tags			alerts
+//	@Security		AdminToken
+//	@Accept			json
+//	@Produce		json
+//	@Param			alert	body		dto.AlertRequestDTO	true	"Alert rule"
+//	@Success		201		{object}	dto.AlertDTO
+//	@Failure		400		{object}	map[string]interface{}	"Invalid input"
+//	@Failure		401		{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403		{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/alerts [post]
+func (s *Server) CreateAlertHandler(rw http.ResponseWriter, req *http.Request) {
+	alert, err := decodeAlert(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	created, err := s.service.CreateAlert(req.Context(), alert)
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+
+	alertDTO := toAlertDTO(*created)
+	alertDTO.Secret = created.Secret
+	writeJSON(rw, http.StatusCreated, alertDTO)
+}
+
+// GetAlertsHandler godoc
+//
+//	@Summary		List alerts
+//	@Tags			alerts
+//	@Security		AdminToken
+//	@Produce		json
+//	@Success		200	{array}		dto.AlertDTO
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/alerts [get]
+func (s *Server) GetAlertsHandler(rw http.ResponseWriter, req *http.Request) {
+	alerts, err := s.service.GetAlerts(req.Context())
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+
+	alertsDTO := make(dto.AlertsDTO, 0, len(alerts))
+	for _, alert := range alerts {
+		alertsDTO = append(alertsDTO, toAlertDTO(alert))
+	}
+	writeJSON(rw, http.StatusOK, alertsDTO)
+}
+
+// GetAlertHandler godoc
+//
+//	@Summary		Get alert
+//	@Tags			alerts
+//	@Security		AdminToken
+//	@Produce		json
+//	@Param			id	path		int	true	"Alert id"
+//	@Success		200	{object}	dto.AlertDTO
+//	@Failure		400	{object}	map[string]interface{}	"Invalid input"
+//	@Failure		404	{object}	map[string]interface{}	"No alert found"
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/alerts/{id} [get]
+func (s *Server) GetAlertHandler(rw http.ResponseWriter, req *http.Request) {
+	id, err := parseAlertID(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	alert, err := s.service.GetAlert(req.Context(), id)
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+	writeJSON(rw, http.StatusOK, toAlertDTO(*alert))
+}
+
+// UpdateAlertHandler godoc
+//
+//	@Summary		Replace alert
+//	@Description	Replace the rule of an alert. An empty secret keeps the current one
+//	@Tags			alerts
+//	@Security		AdminToken
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Alert id"
+//	@Param			alert	body		dto.AlertRequestDTO	true	"Alert rule"
+//	@Success		200		{object}	dto.AlertDTO
+//	@Failure		400		{object}	map[string]interface{}	"Invalid input"
+//	@Failure		404		{object}	map[string]interface{}	"No alert found"
+//	@Failure		401		{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403		{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/alerts/{id} [put]
+func (s *Server) UpdateAlertHandler(rw http.ResponseWriter, req *http.Request) {
+	id, err := parseAlertID(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	alert, err := decodeAlert(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	alert.ID = id
+
+	updated, err := s.service.UpdateAlert(req.Context(), alert)
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+	writeJSON(rw, http.StatusOK, toAlertDTO(*updated))
+}
+
+// DeleteAlertHandler godoc
+//
+//	@Summary		Delete alert
+//	@Description	Delete an alert together with its delivery log
+//	@Tags			alerts
+//	@Security		AdminToken
+//	@Param			id	path	int	true	"Alert id"
+//	@Success		204
+//	@Failure		400	{object}	map[string]interface{}	"Invalid input"
+//	@Failure		404	{object}	map[string]interface{}	"No alert found"
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/alerts/{id} [delete]
+func (s *Server) DeleteAlertHandler(rw http.ResponseWriter, req *http.Request) {
+	id, err := parseAlertID(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.service.DeleteAlert(req.Context(), id); err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveriesHandler godoc
+//
+//	@Summary		Get alert deliveries
+//	@Description	Get the latest webhook delivery attempts of an alert, newest first
+//	@Tags			alerts
+//	@Security		AdminToken
+//	@Produce		json
+//	@Param			id	path		int	true	"Alert id"
+//	@Success		200	{array}		dto.DeliveryDTO
+//	@Failure		400	{object}	map[string]interface{}	"Invalid input"
+//	@Failure		404	{object}	map[string]interface{}	"No alert found"
+//	@Failure		401	{object}	map[string]interface{}	"Invalid admin token"
+//	@Failure		403	{object}	map[string]interface{}	"Admin API is disabled"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/alerts/{id}/deliveries [get]
+func (s *Server) GetDeliveriesHandler(rw http.ResponseWriter, req *http.Request) {
+	id, err := parseAlertID(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := s.service.GetDeliveries(req.Context(), id)
+	if err != nil {
+		http.Error(rw, err.Error(), errorStatus(err))
+		return
+	}
+
+	deliveriesDTO := make(dto.DeliveriesDTO, 0, len(deliveries))
+	for _, d := range deliveries {
+		deliveriesDTO = append(deliveriesDTO, dto.DeliveryDTO{
+			ID:         d.ID,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Error:      d.Error,
+			CreateTime: d.CreateTime.Format(time.RFC3339),
+		})
+	}
+	writeJSON(rw, http.StatusOK, deliveriesDTO)
+}
@@ -0,0 +1,254 @@
+package public_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/ports/http/public"
+	mock "currency/internal/ports/http/public/mocks"
+	"currency/pkg/dto"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAlertServer returns a server with the admin API, which the alert routes
+// belong to, enabled.
+func newAlertServer(t *testing.T) (*public.Server, *mock.MockService) {
+	server, service := newServer(t)
+	server.SetAdminToken(adminToken)
+	return server, service
+}
+
+func alertRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	return req
+}
+
+func TestServer_AlertHandlers_AdminAuth(t *testing.T) {
+	server, _ := newServer(t)
+	server.SetAdminToken(adminToken)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/alerts", strings.NewReader(`{}`)),
+		httptest.NewRequest(http.MethodGet, "/v1/alerts", nil),
+		httptest.NewRequest(http.MethodDelete, "/v1/alerts/1", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/alerts/1/deliveries", nil),
+	} {
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, req.URL.Path)
+	}
+}
+
+func TestServer_CreateAlertHandler(t *testing.T) {
+	body := `{"title":"btc","quote":"usd","condition":"above","threshold":100000,"cooldown":"1h","webhook_url":"https://example.com/hook"}`
+
+	t.Run("created", func(t *testing.T) {
+		server, service := newAlertServer(t)
+		service.EXPECT().CreateAlert(gomock.Any(), entities.Alert{
+			Title:      "BTC",
+			Quote:      "USD",
+			Condition:  entities.AlertAbove,
+			Threshold:  decimal.NewFromInt(100000),
+			Cooldown:   time.Hour,
+			WebhookURL: "https://example.com/hook",
+		}).DoAndReturn(func(_ interface{}, a entities.Alert) (*entities.Alert, error) {
+			a.ID, a.Secret, a.CreateTime = 1, "generated", to
+			return &a, nil
+		})
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, alertRequest(http.MethodPost, "/v1/alerts", strings.NewReader(body)))
+		require.Equal(t, http.StatusCreated, rec.Code)
+
+		var alert dto.AlertDTO
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&alert))
+		assert.Equal(t, dto.AlertDTO{
+			ID:         1,
+			Title:      "BTC",
+			Quote:      "USD",
+			Condition:  "above",
+			Threshold:  decimal.NewFromInt(100000),
+			Cooldown:   "1h0m0s",
+			WebhookURL: "https://example.com/hook",
+			Secret:     "generated",
+			CreateTime: "2025-05-17T12:00:00Z",
+		}, alert)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"invalid json":     `{`,
+			"invalid cooldown": `{"cooldown":"soon"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				server, _ := newAlertServer(t)
+
+				rec := httptest.NewRecorder()
+				server.ServeHTTP(rec, alertRequest(http.MethodPost, "/v1/alerts", strings.NewReader(body)))
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			})
+		}
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		server, service := newAlertServer(t)
+		service.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(entities.ErrInvalidParams, "Threshold must be positive"))
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, alertRequest(http.MethodPost, "/v1/alerts", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestServer_AlertHandlers(t *testing.T) {
+	alert := &entities.Alert{
+		ID:          1,
+		Title:       "BTC",
+		Quote:       "USD",
+		Condition:   entities.AlertBelow,
+		Threshold:   decimal.NewFromInt(90000),
+		WebhookURL:  "https://example.com/hook",
+		Secret:      "secret",
+		LastPrice:   decimal.NewFromInt(89000),
+		LastFiredAt: to,
+		CreateTime:  from,
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		prepare    func(s *mock.MockService)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			target: "/v1/alerts",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetAlerts(gomock.Any()).Return([]entities.Alert{*alert}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":1,"title":"BTC","quote":"USD","condition":"below","threshold":"90000","cooldown":"0s",
+				"webhook_url":"https://example.com/hook","last_price":"89000","last_fired_at":"2025-05-17T12:00:00Z",
+				"create_time":"2025-05-16T12:00:00Z"}]`,
+		},
+		{
+			name:   "get",
+			method: http.MethodGet,
+			target: "/v1/alerts/1",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetAlert(gomock.Any(), int64(1)).Return(alert, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "get invalid id",
+			method:     http.MethodGet,
+			target:     "/v1/alerts/abc",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "get not found",
+			method: http.MethodGet,
+			target: "/v1/alerts/2",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetAlert(gomock.Any(), int64(2)).Return(nil, errors.Wrap(entities.ErrNotFound, "GetAlert"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "update",
+			method: http.MethodPut,
+			target: "/v1/alerts/1",
+			body:   `{"title":"BTC","quote":"USD","condition":"below","threshold":"90000","webhook_url":"https://example.com/hook"}`,
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, a entities.Alert) (*entities.Alert, error) {
+					assert.Equal(t, int64(1), a.ID)
+					assert.Equal(t, "90000", a.Threshold.String())
+					return alert, nil
+				})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "update invalid threshold",
+			method:     http.MethodPut,
+			target:     "/v1/alerts/1",
+			body:       `{"title":"BTC","quote":"USD","condition":"below","threshold":"9e","webhook_url":"https://example.com/hook"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "delete",
+			method: http.MethodDelete,
+			target: "/v1/alerts/1",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().DeleteAlert(gomock.Any(), int64(1)).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "delete not found",
+			method: http.MethodDelete,
+			target: "/v1/alerts/2",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().DeleteAlert(gomock.Any(), int64(2)).Return(errors.Wrap(entities.ErrNotFound, "DeleteAlert"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "deliveries",
+			method: http.MethodGet,
+			target: "/v1/alerts/1/deliveries",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetDeliveries(gomock.Any(), int64(1)).Return([]entities.Delivery{
+					{ID: 2, AlertID: 1, Attempt: 2, StatusCode: 200, CreateTime: to},
+					{ID: 1, AlertID: 1, Attempt: 1, StatusCode: 502, Error: "Status Error: 502 Bad Gateway", CreateTime: from},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":2,"attempt":2,"status_code":200,"create_time":"2025-05-17T12:00:00Z"},
+				{"id":1,"attempt":1,"status_code":502,"error":"Status Error: 502 Bad Gateway","create_time":"2025-05-16T12:00:00Z"}]`,
+		},
+		{
+			name:   "storage error",
+			method: http.MethodGet,
+			target: "/v1/alerts",
+			prepare: func(s *mock.MockService) {
+				s.EXPECT().GetAlerts(gomock.Any()).Return(nil, errors.Wrap(entities.ErrGetFunc, "GetAlerts"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, service := newAlertServer(t)
+			if tt.prepare != nil {
+				tt.prepare(service)
+			}
+
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, alertRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.NotContains(t, rec.Body.String(), "secret")
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
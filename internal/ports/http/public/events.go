@@ -0,0 +1,155 @@
+package public
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/usecases"
+)
+
+// eventsHeartbeat keeps idle event streams alive through proxies.
+const eventsHeartbeat = 30 * time.Second
+
+// EventsHandler godoc
+//
+//	@Summary		Price events
+//	@Description	Server-Sent Events stream with one "price" event per stored coin. The event id is the id of the stored price; reconnecting with Last-Event-ID replays the prices stored since then.
+//	@Tags			coins
+//	@Produce		text/event-stream
+//	@Param			fsyms			query		string	false	"Comma-separated list of cryptocurrencies, every coin by default"
+//	@Param			tsyms			query		string	false	"Comma-separated list of quote currencies, all quotes by default"
+//	@Param			Last-Event-ID	header		string	false	"Id of the last event received"
+//	@Success		200				{object}	dto.CoinDTO	"data of each price event"
+//	@Failure		400				{object}	map[string]interface{}	"Invalid input"
+//	@Failure		500				{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/events [get]
+func (s *Server) EventsHandler(rw http.ResponseWriter, req *http.Request) {
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	query := req.URL.Query()
+	var titles []string
+	if fsyms := query.Get("fsyms"); fsyms != "" {
+		titles = strings.Split(strings.ToUpper(fsyms), ",")
+	}
+	quotes := parseQuotes(query.Get("tsyms"))
+
+	var lastID int64
+	if v := req.Header.Get("Last-Event-ID"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id < 0 {
+			http.Error(rw, fmt.Sprintf("invalid Last-Event-ID: %s", v), http.StatusBadRequest)
+			return
+		}
+		lastID = id
+	}
+
+	ctx := req.Context()
+
+	// Subscribe before replaying so nothing stored in between is lost; the
+	// replayed ids let the live part skip duplicates.
+	sub := s.service.Subscribe(titles, quotes)
+	defer sub.Close()
+
+	var replay []entities.Coin
+	if lastID > 0 {
+		coins, err := s.service.GetCoinsAfter(ctx, lastID, titles, quotes)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		replay = coins
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.Header().Set("X-Accel-Buffering", "no")
+	rw.WriteHeader(http.StatusOK)
+
+	// lastID is the high-water mark: events are sent in id order, so it also
+	// skips what the replay and the live part both read.
+	send := func(coins []entities.Coin) error {
+		for _, coin := range coins {
+			if coin.ID <= lastID {
+				continue
+			}
+			data, err := json.Marshal(toCoinsDTO([]entities.Coin{coin})[0])
+			if err != nil {
+				return err
+			}
+			lastID = coin.ID
+			if _, err := fmt.Fprintf(rw, "id: %d\nevent: price\ndata: %s\n\n", coin.ID, data); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+		return nil
+	}
+	// catchUp sends coins and then the rest of the coins stored after them.
+	catchUp := func(coins []entities.Coin) error {
+		for {
+			if err := send(coins); err != nil {
+				return err
+			}
+			if len(coins) < usecases.MaxReplay {
+				return nil
+			}
+			var err error
+			if coins, err = s.service.GetCoinsAfter(ctx, lastID, titles, quotes); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprint(rw, "retry: 5000\n\n")
+	if err := catchUp(replay); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.closing:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(rw, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case _, ok := <-sub.Ready():
+			if !ok {
+				return
+			}
+			// Подписка объединяет цены по паре и сортирует их не по id, поэтому она
+			// только будит поток, а каждую сохранённую цену читаем по порядку id.
+			sub.Next()
+			if lastID == 0 {
+				since := sub.Since()
+				if since == 0 {
+					continue
+				}
+				lastID = since - 1
+			}
+			coins, err := s.service.GetCoinsAfter(ctx, lastID, titles, quotes)
+			if err != nil {
+				return
+			}
+			if err := catchUp(coins); err != nil {
+				return
+			}
+		}
+	}
+}
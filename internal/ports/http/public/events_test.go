@@ -0,0 +1,162 @@
+package public_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/ports/http/public"
+	"currency/internal/usecases"
+	usecasesmock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readEvent reads one SSE event, skipping comments and retry hints.
+func readEvent(t *testing.T, r *bufio.Reader) map[string]string {
+	event := make(map[string]string)
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if _, ok := event["event"]; ok {
+				return event
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		event[field] = value
+	}
+}
+
+// stored is the prices a mocked storage holds, in id order.
+type stored struct {
+	mu    sync.Mutex
+	coins []entities.Coin
+}
+
+func (s *stored) expect(storage *usecasesmock.MockStorage) {
+	storage.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, coins []entities.Coin) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i := range coins {
+			coins[i].ID = int64(len(s.coins) + 1)
+			s.coins = append(s.coins, coins[i])
+		}
+		return nil
+	}).AnyTimes()
+	storage.EXPECT().GetAfter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id int64, titles []string, limit int, _ ...usecases.Option) ([]entities.Coin, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			var coins []entities.Coin
+			for _, coin := range s.coins {
+				if coin.ID > id && len(coins) < limit && (len(titles) == 0 || slices.Contains(titles, coin.Title)) {
+					coins = append(coins, coin)
+				}
+			}
+			return coins, nil
+		}).AnyTimes()
+}
+
+func newEventsServer(t *testing.T) (*usecases.Service, *usecasesmock.MockClient, *httptest.Server) {
+	ctrl := gomock.NewController(t)
+	storage, client := usecasesmock.NewMockStorage(ctrl), usecasesmock.NewMockClient(ctrl)
+	(&stored{}).expect(storage)
+	service, err := usecases.NewService(storage, client, logging.Nop())
+	require.NoError(t, err)
+
+	server, err := public.NewServer(service, "8080", logging.Nop())
+	require.NoError(t, err)
+	testServer := httptest.NewServer(server)
+	t.Cleanup(testServer.Close)
+	return service, client, testServer
+}
+
+func openEvents(t *testing.T, url, lastEventID string) *bufio.Reader {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+func TestServer_EventsHandler(t *testing.T) {
+	service, client, testServer := newEventsServer(t)
+	now := time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+	coin := func(title string, price int64) entities.Coin {
+		return entities.Coin{Title: title, Quote: "USD", Price: decimal.NewFromInt(price), CreateTime: now}
+	}
+	ingest := func(coins ...entities.Coin) {
+		client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+		_, err := service.GetCoinsFromAPI(context.Background(), []string{"BTC", "ETH"}, nil)
+		require.NoError(t, err)
+	}
+	ingest(coin("BTC", 99), coin("BTC", 100))
+
+	body := openEvents(t, testServer.URL+"/v1/events?fsyms=BTC", "1")
+	event := readEvent(t, body)
+	assert.Equal(t, "2", event["id"])
+	assert.Equal(t, "price", event["event"])
+	assert.JSONEq(t, `{"title":"BTC","quote":"USD","price":"100","create_time":"2025-05-17T12:00:00Z"}`, event["data"])
+
+	// Every stored price of fsyms is sent, even two of one pair in a batch.
+	ingest(coin("BTC", 101), coin("BTC", 102), coin("ETH", 10))
+	for _, want := range []string{"3", "4"} {
+		assert.Equal(t, want, readEvent(t, body)["id"])
+	}
+}
+
+func TestServer_EventsHandler_OutOfOrder(t *testing.T) {
+	service, client, testServer := newEventsServer(t)
+	body := openEvents(t, testServer.URL+"/v1/events", "")
+
+	// ETH gets the smaller id, but the subscription orders BTC first.
+	now := time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+	client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entities.Coin{
+		{Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(10), CreateTime: now},
+		{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(100), CreateTime: now},
+	}, nil)
+	_, err := service.GetCoinsFromAPI(context.Background(), []string{"BTC", "ETH"}, nil)
+	require.NoError(t, err)
+
+	first, second := readEvent(t, body), readEvent(t, body)
+	assert.Equal(t, "1", first["id"])
+	assert.Contains(t, first["data"], `"title":"ETH"`)
+	assert.Equal(t, "2", second["id"])
+	assert.Contains(t, second["data"], `"title":"BTC"`)
+}
+
+func TestServer_EventsHandler_InvalidLastEventID(t *testing.T) {
+	server, _ := newServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/events", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
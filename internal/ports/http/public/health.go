@@ -0,0 +1,80 @@
+package public
+
+import (
+	"net/http"
+	"time"
+
+	"currency/pkg/dto"
+)
+
+// HealthzHandler godoc
+//
+//	@Summary		Liveness probe
+//	@Description	Always 200 while the process serves requests
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	map[string]string
+//	@Router			/healthz [get]
+func (s *Server) HealthzHandler(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ReadyzHandler godoc
+//
+//	@Summary		Readiness probe
+//	@Description	200 when the database answers and prices were fetched from upstream within the staleness window, 503 otherwise
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	dto.ReadinessDTO
+//	@Failure		503	{object}	dto.ReadinessDTO
+//	@Router			/readyz [get]
+func (s *Server) ReadyzHandler(rw http.ResponseWriter, req *http.Request) {
+	readiness := dto.ReadinessDTO{Status: "ok", Checks: make(map[string]string)}
+	status := http.StatusOK
+	for name, err := range s.service.Ready(req.Context()) {
+		if err != nil {
+			readiness.Status = "unavailable"
+			readiness.Checks[name] = err.Error()
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		readiness.Checks[name] = "ok"
+	}
+	writeJSON(rw, status, readiness)
+}
+
+// StatusHandler godoc
+//
+//	@Summary		Get status
+//	@Description	Get the last successful upstream fetch, the last update of every stored pair and whether this instance is the leader that ingests prices
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	dto.StatusDTO
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/status [get]
+func (s *Server) StatusHandler(rw http.ResponseWriter, req *http.Request) {
+	status, err := s.service.Status(req.Context())
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	statusDTO := dto.StatusDTO{
+		Symbols:        make([]dto.SymbolStatusDTO, 0, len(status.Symbols)),
+		Instance:       status.Instance,
+		Leader:         status.Leader,
+		LeaderInstance: status.LeaderInstance,
+	}
+	if !status.LastFetch.IsZero() {
+		statusDTO.LastFetch = status.LastFetch.UTC().Format(time.RFC3339)
+	}
+	for _, symbol := range status.Symbols {
+		statusDTO.Symbols = append(statusDTO.Symbols, dto.SymbolStatusDTO{
+			Title:      symbol.Title,
+			Quote:      symbol.Quote,
+			UpdateTime: symbol.UpdateTime.UTC().Format(time.RFC3339),
+			Stale:      symbol.Stale,
+		})
+	}
+	writeJSON(rw, http.StatusOK, statusDTO)
+}
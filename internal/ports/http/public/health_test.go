@@ -0,0 +1,104 @@
+package public_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"currency/internal/entities"
+	"currency/internal/usecases"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_HealthzHandler(t *testing.T) {
+	server, _ := newServer(t)
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestServer_ReadyzHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "ready",
+			checks:     map[string]error{usecases.CheckStorage: nil, usecases.CheckUpstream: nil},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"ok","checks":{"storage":"ok","upstream":"ok"}}`,
+		},
+		{
+			name: "upstream stale",
+			checks: map[string]error{
+				usecases.CheckStorage:  nil,
+				usecases.CheckUpstream: errors.New("no successful fetch yet"),
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `{"status":"unavailable","checks":{"storage":"ok","upstream":"no successful fetch yet"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, service := newServer(t)
+			service.EXPECT().Ready(gomock.Any()).Return(tt.checks)
+
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestServer_StatusHandler(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().Status(gomock.Any()).Return(&entities.Status{
+			LastFetch: to,
+			Symbols: []entities.SymbolStatus{
+				{Title: "BTC", Quote: "USD", UpdateTime: to},
+				{Title: "ETH", Quote: "USD", UpdateTime: from, Stale: true},
+			},
+			Instance:       "coinapp-1",
+			Leader:         true,
+			LeaderInstance: "coinapp-1",
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"last_fetch":"2025-05-17T12:00:00Z","symbols":[
+			{"title":"BTC","quote":"USD","update_time":"2025-05-17T12:00:00Z","stale":false},
+			{"title":"ETH","quote":"USD","update_time":"2025-05-16T12:00:00Z","stale":true}],
+			"instance":"coinapp-1","leader":true,"leader_instance":"coinapp-1"}`, rec.Body.String())
+	})
+
+	t.Run("nothing stored", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().Status(gomock.Any()).Return(&entities.Status{}, nil)
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"symbols":[],"leader":false}`, rec.Body.String())
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().Status(gomock.Any()).Return(nil, errors.Wrap(entities.ErrGetFunc, "Status"))
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -0,0 +1,369 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	entities "currency/internal/entities"
+	usecases "currency/internal/usecases"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AddSymbol mocks base method.
+func (m *MockService) AddSymbol(ctx context.Context, title string) (*entities.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSymbol", ctx, title)
+	ret0, _ := ret[0].(*entities.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSymbol indicates an expected call of AddSymbol.
+func (mr *MockServiceMockRecorder) AddSymbol(ctx, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSymbol", reflect.TypeOf((*MockService)(nil).AddSymbol), ctx, title)
+}
+
+// CreateAlert mocks base method.
+func (m *MockService) CreateAlert(ctx context.Context, alert entities.Alert) (*entities.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlert", ctx, alert)
+	ret0, _ := ret[0].(*entities.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlert indicates an expected call of CreateAlert.
+func (mr *MockServiceMockRecorder) CreateAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlert", reflect.TypeOf((*MockService)(nil).CreateAlert), ctx, alert)
+}
+
+// DeleteAlert mocks base method.
+func (m *MockService) DeleteAlert(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlert", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlert indicates an expected call of DeleteAlert.
+func (mr *MockServiceMockRecorder) DeleteAlert(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlert", reflect.TypeOf((*MockService)(nil).DeleteAlert), ctx, id)
+}
+
+// DeleteSymbol mocks base method.
+func (m *MockService) DeleteSymbol(ctx context.Context, title string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSymbol", ctx, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSymbol indicates an expected call of DeleteSymbol.
+func (mr *MockServiceMockRecorder) DeleteSymbol(ctx, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSymbol", reflect.TypeOf((*MockService)(nil).DeleteSymbol), ctx, title)
+}
+
+// FetchMissing mocks base method.
+func (m *MockService) FetchMissing(ctx context.Context, titles, quotes []string) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMissing", ctx, titles, quotes)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMissing indicates an expected call of FetchMissing.
+func (mr *MockServiceMockRecorder) FetchMissing(ctx, titles, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMissing", reflect.TypeOf((*MockService)(nil).FetchMissing), ctx, titles, quotes)
+}
+
+// GetAlert mocks base method.
+func (m *MockService) GetAlert(ctx context.Context, id int64) (*entities.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlert", ctx, id)
+	ret0, _ := ret[0].(*entities.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlert indicates an expected call of GetAlert.
+func (mr *MockServiceMockRecorder) GetAlert(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlert", reflect.TypeOf((*MockService)(nil).GetAlert), ctx, id)
+}
+
+// GetAlerts mocks base method.
+func (m *MockService) GetAlerts(ctx context.Context) ([]entities.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", ctx)
+	ret0, _ := ret[0].([]entities.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockServiceMockRecorder) GetAlerts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockService)(nil).GetAlerts), ctx)
+}
+
+// GetAvgPrice mocks base method.
+func (m *MockService) GetAvgPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAvgPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvgPrice indicates an expected call of GetAvgPrice.
+func (mr *MockServiceMockRecorder) GetAvgPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvgPrice", reflect.TypeOf((*MockService)(nil).GetAvgPrice), varargs...)
+}
+
+// GetCandles mocks base method.
+func (m *MockService) GetCandles(ctx context.Context, titles, quotes []string, interval time.Duration, from, to time.Time) ([]entities.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandles", ctx, titles, quotes, interval, from, to)
+	ret0, _ := ret[0].([]entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockServiceMockRecorder) GetCandles(ctx, titles, quotes, interval, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockService)(nil).GetCandles), ctx, titles, quotes, interval, from, to)
+}
+
+// GetCoinsAfter mocks base method.
+func (m *MockService) GetCoinsAfter(ctx context.Context, id int64, titles, quotes []string) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinsAfter", ctx, id, titles, quotes)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinsAfter indicates an expected call of GetCoinsAfter.
+func (mr *MockServiceMockRecorder) GetCoinsAfter(ctx, id, titles, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsAfter", reflect.TypeOf((*MockService)(nil).GetCoinsAfter), ctx, id, titles, quotes)
+}
+
+// GetCoinsFromAPI mocks base method.
+func (m *MockService) GetCoinsFromAPI(ctx context.Context, titles, quotes []string) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinsFromAPI", ctx, titles, quotes)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinsFromAPI indicates an expected call of GetCoinsFromAPI.
+func (mr *MockServiceMockRecorder) GetCoinsFromAPI(ctx, titles, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsFromAPI", reflect.TypeOf((*MockService)(nil).GetCoinsFromAPI), ctx, titles, quotes)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockService) GetDeliveries(ctx context.Context, alertID int64) ([]entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, alertID)
+	ret0, _ := ret[0].([]entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockServiceMockRecorder) GetDeliveries(ctx, alertID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockService)(nil).GetDeliveries), ctx, alertID)
+}
+
+// GetLastPrice mocks base method.
+func (m *MockService) GetLastPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLastPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastPrice indicates an expected call of GetLastPrice.
+func (mr *MockServiceMockRecorder) GetLastPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPrice", reflect.TypeOf((*MockService)(nil).GetLastPrice), varargs...)
+}
+
+// GetMaxPrice mocks base method.
+func (m *MockService) GetMaxPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMaxPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaxPrice indicates an expected call of GetMaxPrice.
+func (mr *MockServiceMockRecorder) GetMaxPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxPrice", reflect.TypeOf((*MockService)(nil).GetMaxPrice), varargs...)
+}
+
+// GetMinPrice mocks base method.
+func (m *MockService) GetMinPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, quotes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMinPrice", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMinPrice indicates an expected call of GetMinPrice.
+func (mr *MockServiceMockRecorder) GetMinPrice(ctx, titles, quotes interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, quotes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinPrice", reflect.TypeOf((*MockService)(nil).GetMinPrice), varargs...)
+}
+
+// GetSymbols mocks base method.
+func (m *MockService) GetSymbols(ctx context.Context) ([]entities.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbols", ctx)
+	ret0, _ := ret[0].([]entities.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSymbols indicates an expected call of GetSymbols.
+func (mr *MockServiceMockRecorder) GetSymbols(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbols", reflect.TypeOf((*MockService)(nil).GetSymbols), ctx)
+}
+
+// Ready mocks base method.
+func (m *MockService) Ready(ctx context.Context) map[string]error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(map[string]error)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockServiceMockRecorder) Ready(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockService)(nil).Ready), ctx)
+}
+
+// SetSymbolPaused mocks base method.
+func (m *MockService) SetSymbolPaused(ctx context.Context, title string, paused bool) (*entities.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSymbolPaused", ctx, title, paused)
+	ret0, _ := ret[0].(*entities.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSymbolPaused indicates an expected call of SetSymbolPaused.
+func (mr *MockServiceMockRecorder) SetSymbolPaused(ctx, title, paused interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSymbolPaused", reflect.TypeOf((*MockService)(nil).SetSymbolPaused), ctx, title, paused)
+}
+
+// Status mocks base method.
+func (m *MockService) Status(ctx context.Context) (*entities.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].(*entities.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockServiceMockRecorder) Status(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockService)(nil).Status), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockService) Subscribe(titles, quotes []string) *usecases.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", titles, quotes)
+	ret0, _ := ret[0].(*usecases.Subscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockServiceMockRecorder) Subscribe(titles, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockService)(nil).Subscribe), titles, quotes)
+}
+
+// UpdateAlert mocks base method.
+func (m *MockService) UpdateAlert(ctx context.Context, alert entities.Alert) (*entities.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlert", ctx, alert)
+	ret0, _ := ret[0].(*entities.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlert indicates an expected call of UpdateAlert.
+func (mr *MockServiceMockRecorder) UpdateAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlert", reflect.TypeOf((*MockService)(nil).UpdateAlert), ctx, alert)
+}
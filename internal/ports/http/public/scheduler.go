@@ -0,0 +1,16 @@
+package public
+
+import (
+	"context"
+
+	"currency/internal/entities"
+	"currency/internal/scheduler"
+)
+
+//go:generate mockgen -source=scheduler.go -destination=./mocks/scheduler_mock.go -package=mock
+type Scheduler interface {
+	Refresh(ctx context.Context) ([]entities.Coin, error)
+	Pause()
+	Resume()
+	Status() scheduler.Status
+}
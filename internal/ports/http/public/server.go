@@ -1,18 +1,27 @@
 package public
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"math"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/metrics"
+	"currency/internal/tracing"
+	"currency/internal/usecases"
 	"currency/pkg/dto"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/pkg/errors"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 //	@title			Coin API
@@ -24,232 +33,417 @@ import (
 //	@host			localhost:8080
 //	@BasePath		/v1
 
+//	@securityDefinitions.apikey	AdminToken
+//	@in							header
+//	@name						Authorization
+//	@description				Bearer followed by the admin token
+
 // @externalDocs.description  OpenAPI
 // @externalDocs.url          https://swagger.io/resources/open-api/
 
 type Server struct {
-	port    string
 	r       *chi.Mux
+	http    *http.Server
 	service Service
+	// closing is closed on Shutdown to end the streaming handlers, which
+	// http.Server.Shutdown doesn't wait for or interrupt.
+	closing chan struct{}
+	once    sync.Once
+	// adminToken guards /v1/admin; empty disables it.
+	adminToken string
+	scheduler  Scheduler
 }
 
-func NewServer(service Service, port string) (*Server, error) {
+func NewServer(service Service, port string, logger *slog.Logger) (*Server, error) {
 	if service == nil || service == Service(nil) {
 		return nil, errors.Wrap(entities.ErrInvalidParams, "service is nil")
 	}
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
 
 	r := chi.NewRouter()
-	return &Server{port: port, r: r, service: service}, nil
-}
+	s := &Server{r: r, service: service, closing: make(chan struct{})}
+	s.http = &http.Server{Addr: fmt.Sprintf(":%s", port), Handler: r}
+	s.http.RegisterOnShutdown(func() { s.once.Do(func() { close(s.closing) }) })
+
+	s.r.Use(
+		logging.Middleware(logger),
+		metrics.Middleware,
+		otelhttp.NewMiddleware("http.server", otelhttp.WithFilter(func(req *http.Request) bool {
+			switch req.URL.Path {
+			case "/metrics", "/healthz", "/readyz":
+				return false
+			}
+			return true
+		})),
+		tracing.RouteName,
+	)
 
-func (s *Server) Run() error {
 	s.r.Get("/v1/get_current_rate", s.GetLastPriceHandler)
 	s.r.Get("/v1/get_max_rate", s.GetMaxPriceHandler)
 	s.r.Get("/v1/get_min_rate", s.GetMinPriceHandler)
 	s.r.Get("/v1/get_avg_rate", s.GetAvgPriceHandler)
+	s.r.Get("/v1/candles", s.GetCandlesHandler)
+	s.r.Get("/v1/stream", s.StreamHandler)
+	s.r.Get("/v1/events", s.EventsHandler)
+	s.r.Route("/v1/alerts", func(r chi.Router) {
+		// Алерты шлют запросы от имени сервиса, поэтому только для админа.
+		r.Use(s.adminAuth)
+		r.Post("/", s.CreateAlertHandler)
+		r.Get("/", s.GetAlertsHandler)
+		r.Get("/{id}", s.GetAlertHandler)
+		r.Put("/{id}", s.UpdateAlertHandler)
+		r.Delete("/{id}", s.DeleteAlertHandler)
+		r.Get("/{id}/deliveries", s.GetDeliveriesHandler)
+	})
+	s.r.Get("/v1/status", s.StatusHandler)
+	s.r.Route("/v1/admin", func(r chi.Router) {
+		r.Use(s.adminAuth)
+		r.Get("/symbols", s.GetSymbolsHandler)
+		r.Post("/symbols", s.AddSymbolHandler)
+		r.Patch("/symbols/{title}", s.PauseSymbolHandler)
+		r.Delete("/symbols/{title}", s.DeleteSymbolHandler)
+		r.Get("/scheduler", s.withScheduler(s.GetSchedulerHandler))
+		r.Post("/scheduler/refresh", s.withScheduler(s.RefreshHandler))
+		r.Post("/scheduler/pause", s.withScheduler(s.PauseSchedulerHandler))
+		r.Post("/scheduler/resume", s.withScheduler(s.ResumeSchedulerHandler))
+	})
 
+	s.r.Get("/healthz", s.HealthzHandler)
+	s.r.Get("/readyz", s.ReadyzHandler)
+
+	s.r.Handle("/metrics", metrics.Handler())
 	s.r.Handle("/swagger.json", http.FileServer(http.Dir("./docs")))
 	s.r.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL("/swagger.json")))
 
-	err := http.ListenAndServe(fmt.Sprintf(":%s", s.port), s.r)
-	if err != nil {
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	s.r.ServeHTTP(rw, req)
+}
+
+// Run serves until Shutdown.
+func (s *Server) Run() error {
+	err := s.http.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return errors.Wrap(entities.ErrInternalServer, err.Error())
 	}
 
 	return nil
 }
 
-// GetLastPriceHandler godoc
-//
-//	@Summary		Get current rate
-//	@Description	Get the current rate of specified coins
-//	@Tags			coins
-//	@Accept			json
-//	@Produce		json
-//	@Param			fsyms	query		string	true	"Comma-separated list of cryptocurrencies"
-//	@Success		200		{object}		dto.CoinsDTO "List of cryptocurrencies"
-//	@Failure		400
-//	@Failure		404
-//	@Failure		500
-//	@Router			/v1/get_current_rate [get]
-func (s *Server) GetLastPriceHandler(rw http.ResponseWriter, req *http.Request) {
-	titles := strings.Split(req.URL.Query().Get("fsyms"), ",")
-	ctx := req.Context()
+// Shutdown stops accepting connections, ends the streams and waits for the
+// other in-flight requests until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.http.Shutdown(ctx); err != nil {
+		return errors.Wrap(entities.ErrInternalServer, err.Error())
+	}
+	return nil
+}
 
-	coins, err := s.service.GetLastPrice(ctx, titles) // Пытаемся взять из БД
-	if err != nil {
-		if errors.Is(err, entities.ErrInvalidParams) { // В БД не нашли таких titles
-			cs, err := s.service.GetCoinsFromAPI(ctx, titles...) // Берем из API
-			if err != nil {
-				fmt.Println(err)
-				http.Error(rw, err.Error(), http.StatusBadRequest)
-				return
-			}
-			coins = cs
+// priceFunc is the shape shared by every Service price query.
+type priceFunc func(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+
+// parseTitles splits the required fsyms parameter.
+func parseTitles(param string) ([]string, error) {
+	if param == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "fsyms is empty")
+	}
+	titles := strings.Split(strings.ToUpper(param), ",")
+	for _, t := range titles {
+		if t == "" {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("fsyms: %s", param))
 		}
-		if !errors.Is(err, entities.ErrInvalidParams) {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
+	}
+	return titles, nil
+}
+
+// parseQuotes splits the tsyms parameter. An empty parameter yields nil,
+// which means "every quote".
+func parseQuotes(param string) []string {
+	if param == "" {
+		return nil
+	}
+	return strings.Split(strings.ToUpper(param), ",")
+}
+
+// parseRange turns the from, to and window parameters into service options.
+// from and to are RFC 3339 timestamps, window is relative to now (24h, 7d).
+func parseRange(query url.Values) ([]usecases.Option, error) {
+	var opts []usecases.Option
+
+	if w := query.Get("window"); w != "" {
+		if query.Get("from") != "" || query.Get("to") != "" {
+			return nil, errors.Wrap(entities.ErrInvalidParams, "window can't be combined with from/to")
+		}
+		d, err := usecases.ParseWindow(w)
+		if err != nil {
+			return nil, err
+		}
+		return append(opts, usecases.WithWindow(d)), nil
+	}
+
+	var from, to time.Time
+	for name, bound := range map[string]*time.Time{"from": &from, "to": &to} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("%s: %s", name, v))
+		}
+		// pgtype пишет timestamp без зоны, поэтому смещение переводится в UTC здесь.
+		*bound = t.UTC()
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "from is after to")
+	}
+	if !from.IsZero() || !to.IsZero() {
+		opts = append(opts, usecases.WithRange(from, to))
+	}
+
+	return opts, nil
+}
+
+// handlePrices is the request pipeline shared by the rate handlers. Titles
+// missing from storage are fetched from the external API once and the query is
+// repeated, so aggregates are always computed by get. withRange enables the
+// from, to and window parameters.
+func (s *Server) handlePrices(rw http.ResponseWriter, req *http.Request, get priceFunc, withRange bool) {
+	query := req.URL.Query()
+
+	titles, err := parseTitles(query.Get("fsyms"))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	quotes := parseQuotes(query.Get("tsyms"))
+
+	var opts []usecases.Option
+	if withRange {
+		opts, err = parseRange(query)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
 			return
 		}
 	}
 
-	var coinsDTO dto.CoinsDTO
-	for _, coin := range coins {
-		coinsDTO = append(coinsDTO, dto.CoinDTO{
-			Title:      coin.Title,
-			Price:      math.Round(coin.Price*100) / 100,
-			CreateTime: coin.CreateTime.Format("2006-02-02"),
-		})
+	ctx := req.Context()
+
+	coins, err := get(ctx, titles, quotes, opts...) // Пытаемся взять из БД
+	if errors.Is(err, entities.ErrNotFound) {       // В БД никогда не было некоторых titles
+		if _, err := s.service.FetchMissing(ctx, titles, quotes); err != nil { // Берем из API только их
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		coins, err = get(ctx, titles, quotes, opts...)
+	}
+	if errors.Is(err, entities.ErrNotFound) {
+		http.Error(rw, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, entities.ErrInvalidParams) {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(coins) == 0 {
+		http.Error(rw, "no coins found", http.StatusNotFound)
+		return
 	}
 
 	rw.Header().Add("Content-Type", "application/json")
-	if err := json.NewEncoder(rw).Encode(coinsDTO); err != nil {
+	if err := json.NewEncoder(rw).Encode(toCoinsDTO(coins)); err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
 
+func toCoinsDTO(coins []entities.Coin) dto.CoinsDTO {
+	coinsDTO := make(dto.CoinsDTO, 0, len(coins))
+	for _, coin := range coins {
+		coinDTO := dto.CoinDTO{
+			Title:      coin.Title,
+			Quote:      coin.Quote,
+			Price:      coin.Price,
+			CreateTime: coin.CreateTime.Format(time.RFC3339),
+			Provider:   coin.Provider,
+		}
+		if !coin.From.IsZero() {
+			coinDTO.From = coin.From.Format(time.RFC3339)
+			coinDTO.To = coin.CreateTime.Format(time.RFC3339)
+		}
+		coinsDTO = append(coinsDTO, coinDTO)
+	}
+	return coinsDTO
+}
+
+// GetLastPriceHandler godoc
+//
+//	@Summary		Get current rate
+//	@Description	Get the current rate of specified coins
+//	@Tags			coins
+//	@Accept			json
+//	@Produce		json
+//	@Param			fsyms	query		string	true	"Comma-separated list of cryptocurrencies"
+//	@Param			tsyms	query		string	false	"Comma-separated list of quote currencies, all stored quotes by default"
+//	@Success		200		{array}		dto.CoinDTO
+//	@Failure		400		{object}	map[string]interface{}	"Invalid input"
+//	@Failure		404		{object}	map[string]interface{}	"No coins found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/get_current_rate [get]
+func (s *Server) GetLastPriceHandler(rw http.ResponseWriter, req *http.Request) {
+	s.handlePrices(rw, req, s.service.GetLastPrice, false)
+}
+
 // GetMaxPriceHandler godoc
 //
 //	@Summary		Get max rate
-//	@Description	Get the max rate of specified coins
+//	@Description	Get the max rate of specified coins over a time range, all history by default
 //	@Tags			coins
 //	@Accept			json
 //	@Produce		json
 //	@Param			fsyms	query		string	true	"Comma-separated list of cryptocurrencies"
+//	@Param			tsyms	query		string	false	"Comma-separated list of quote currencies, all stored quotes by default"
+//	@Param			from	query		string	false	"Start of the range, RFC 3339"
+//	@Param			to		query		string	false	"End of the range, RFC 3339"
+//	@Param			window	query		string	false	"Range relative to now, e.g. 24h or 7d; excludes from/to"
 //	@Success		200		{array}		dto.CoinDTO
 //	@Failure		400		{object}	map[string]interface{}	"Invalid input"
 //	@Failure		404		{object}	map[string]interface{}	"No coins found"
 //	@Failure		500		{object}	map[string]interface{}	"Internal server error"
 //	@Router			/v1/get_max_rate [get]
 func (s *Server) GetMaxPriceHandler(rw http.ResponseWriter, req *http.Request) {
-	titles := strings.Split(req.URL.Query().Get("fsyms"), ",")
-	ctx := req.Context()
-
-	coins, err := s.service.GetLastPrice(ctx, titles)
-	if err != nil {
-		if errors.Is(err, entities.ErrInvalidParams) {
-			cs, err := s.service.GetCoinsFromAPI(ctx, titles...)
-			if err != nil {
-				http.Error(rw, err.Error(), http.StatusBadRequest)
-			}
-			coins = cs
-		}
-		if !errors.Is(err, entities.ErrInvalidParams) {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			return
-		}
-	}
-
-	var coinsDTO dto.CoinsDTO
-	for _, coin := range coins {
-		coinsDTO = append(coinsDTO, dto.CoinDTO{
-			Title:      coin.Title,
-			Price:      math.Round(coin.Price*100) / 100,
-			CreateTime: coin.CreateTime.Format("2006-02-02"),
-		})
-	}
-
-	rw.Header().Add("Content-Type", "application/json")
-	if err := json.NewEncoder(rw).Encode(coinsDTO); err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	//rw.WriteHeader(http.StatusOK)
+	s.handlePrices(rw, req, s.service.GetMaxPrice, true)
 }
 
 // GetMinPriceHandler godoc
 //
 //	@Summary		Get min rate
-//	@Description	Get the min rate of specified coins
+//	@Description	Get the min rate of specified coins over a time range, all history by default
 //	@Tags			coins
 //	@Accept			json
 //	@Produce		json
 //	@Param			fsyms	query		string	true	"Comma-separated list of cryptocurrencies"
+//	@Param			tsyms	query		string	false	"Comma-separated list of quote currencies, all stored quotes by default"
+//	@Param			from	query		string	false	"Start of the range, RFC 3339"
+//	@Param			to		query		string	false	"End of the range, RFC 3339"
+//	@Param			window	query		string	false	"Range relative to now, e.g. 24h or 7d; excludes from/to"
 //	@Success		200		{array}		dto.CoinDTO
 //	@Failure		400		{object}	map[string]interface{}	"Invalid input"
 //	@Failure		404		{object}	map[string]interface{}	"No coins found"
 //	@Failure		500		{object}	map[string]interface{}	"Internal server error"
 //	@Router			/v1/get_min_rate [get]
 func (s *Server) GetMinPriceHandler(rw http.ResponseWriter, req *http.Request) {
-	titles := strings.Split(req.URL.Query().Get("fsyms"), ",")
-	ctx := req.Context()
-
-	coins, err := s.service.GetLastPrice(ctx, titles)
-	if err != nil {
-		if errors.Is(err, entities.ErrInvalidParams) {
-			cs, err := s.service.GetCoinsFromAPI(ctx, titles...)
-			if err != nil {
-				http.Error(rw, err.Error(), http.StatusBadRequest)
-			}
-			coins = cs
-		}
-		if !errors.Is(err, entities.ErrInvalidParams) {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			return
-		}
-	}
-
-	var coinsDTO dto.CoinsDTO
-	for _, coin := range coins {
-		coinsDTO = append(coinsDTO, dto.CoinDTO{
-			Title:      coin.Title,
-			Price:      math.Round(coin.Price*100) / 100,
-			CreateTime: coin.CreateTime.Format("2006-02-02"),
-		})
-	}
-
-	rw.Header().Add("Content-Type", "application/json")
-	if err := json.NewEncoder(rw).Encode(coinsDTO); err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	s.handlePrices(rw, req, s.service.GetMinPrice, true)
 }
 
 // GetAvgPriceHandler godoc
 //
 //	@Summary		Get avg rate
-//	@Description	Get the avg rate of specified coins
+//	@Description	Get the avg rate of specified coins over a time range, all history by default
 //	@Tags			coins
 //	@Accept			json
 //	@Produce		json
 //	@Param			fsyms	query		string	true	"Comma-separated list of cryptocurrencies"
+//	@Param			tsyms	query		string	false	"Comma-separated list of quote currencies, all stored quotes by default"
+//	@Param			from	query		string	false	"Start of the range, RFC 3339"
+//	@Param			to		query		string	false	"End of the range, RFC 3339"
+//	@Param			window	query		string	false	"Range relative to now, e.g. 24h or 7d; excludes from/to"
 //	@Success		200		{array}		dto.CoinDTO
 //	@Failure		400		{object}	map[string]interface{}	"Invalid input"
 //	@Failure		404		{object}	map[string]interface{}	"No coins found"
 //	@Failure		500		{object}	map[string]interface{}	"Internal server error"
 //	@Router			/v1/get_avg_rate [get]
 func (s *Server) GetAvgPriceHandler(rw http.ResponseWriter, req *http.Request) {
-	titles := strings.Split(req.URL.Query().Get("fsyms"), ",")
-	ctx := req.Context()
+	s.handlePrices(rw, req, s.service.GetAvgPrice, true)
+}
 
-	coins, err := s.service.GetLastPrice(ctx, titles)
+// GetCandlesHandler godoc
+//
+//	@Summary		Get candles
+//	@Description	Get open/high/low/close candles of specified coins, one per interval bucket per coin
+//	@Tags			coins
+//	@Accept			json
+//	@Produce		json
+//	@Param			fsyms		query		string	true	"Comma-separated list of cryptocurrencies"
+//	@Param			tsyms		query		string	false	"Comma-separated list of quote currencies, all stored quotes by default"
+//	@Param			interval	query		string	false	"Bucket size: 1m, 5m, 1h or 1d"	default(1h)
+//	@Param			from		query		string	false	"Start of the range, RFC 3339"
+//	@Param			to			query		string	false	"End of the range, RFC 3339, now by default"
+//	@Param			window		query		string	false	"Range relative to now, e.g. 24h or 7d; excludes from/to"
+//	@Success		200			{array}		dto.CandleDTO
+//	@Failure		400			{object}	map[string]interface{}	"Invalid input"
+//	@Failure		404			{object}	map[string]interface{}	"No coins found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Router			/v1/candles [get]
+func (s *Server) GetCandlesHandler(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	titles, err := parseTitles(query.Get("fsyms"))
 	if err != nil {
-		if errors.Is(err, entities.ErrInvalidParams) {
-			cs, err := s.service.GetCoinsFromAPI(ctx, titles...)
-			if err != nil {
-				http.Error(rw, err.Error(), http.StatusBadRequest)
-			}
-			coins = cs
-		}
-		if !errors.Is(err, entities.ErrInvalidParams) {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			return
-		}
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
 	}
+	quotes := parseQuotes(query.Get("tsyms"))
 
-	var coinsDTO dto.CoinsDTO
-	for _, coin := range coins {
-		coinsDTO = append(coinsDTO, dto.CoinDTO{
-			Title:      coin.Title,
-			Price:      math.Round(coin.Price*100) / 100,
-			CreateTime: coin.CreateTime.Format("2006-02-02"),
+	param := query.Get("interval")
+	if param == "" {
+		param = "1h"
+	}
+	interval, err := usecases.ParseInterval(param)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := parseRange(query)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	o := &usecases.Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	from, to := o.Range(time.Now())
+
+	candles, err := s.service.GetCandles(req.Context(), titles, quotes, interval, from, to)
+	if errors.Is(err, entities.ErrInvalidParams) {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(candles) == 0 {
+		http.Error(rw, "no candles found", http.StatusNotFound)
+		return
+	}
+
+	candlesDTO := make(dto.CandlesDTO, 0, len(candles))
+	for _, c := range candles {
+		candlesDTO = append(candlesDTO, dto.CandleDTO{
+			Title: c.Title,
+			Quote: c.Quote,
+			Start: c.Start.Format(time.RFC3339),
+			End:   c.Start.Add(c.Interval).Format(time.RFC3339),
+			Open:  c.Open,
+			High:  c.High,
+			Low:   c.Low,
+			Close: c.Close,
 		})
 	}
 
 	rw.Header().Add("Content-Type", "application/json")
-	if err := json.NewEncoder(rw).Encode(coinsDTO); err != nil {
+	if err := json.NewEncoder(rw).Encode(candlesDTO); err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
 		return
 	}
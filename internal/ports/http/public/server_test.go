@@ -0,0 +1,324 @@
+package public_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/ports/http/public"
+	mock "currency/internal/ports/http/public/mocks"
+	"currency/internal/usecases"
+	"currency/pkg/dto"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	from = time.Date(2025, 5, 16, 12, 0, 0, 0, time.UTC)
+	to   = time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+)
+
+func newServer(t *testing.T) (*public.Server, *mock.MockService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	service := mock.NewMockService(ctrl)
+	server, err := public.NewServer(service, "8080", logging.Nop())
+	require.NoError(t, err)
+
+	return server, service
+}
+
+func doRequest(t *testing.T, server http.Handler, target string) (*httptest.ResponseRecorder, dto.CoinsDTO) {
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var coins dto.CoinsDTO
+	if rec.Code == http.StatusOK {
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&coins))
+	}
+	return rec, coins
+}
+
+func TestServer_AggregateHandlers(t *testing.T) {
+	aggregate := []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.RequireFromString("103512.456"), CreateTime: to, From: from}}
+
+	tests := []struct {
+		name   string
+		target string
+		expect func(s *mock.MockService) *gomock.Call
+	}{
+		{
+			name:   "max",
+			target: "/v1/get_max_rate?fsyms=BTC&tsyms=USD",
+			expect: func(s *mock.MockService) *gomock.Call {
+				return s.EXPECT().GetMaxPrice(gomock.Any(), []string{"BTC"}, []string{"USD"})
+			},
+		},
+		{
+			name:   "min",
+			target: "/v1/get_min_rate?fsyms=BTC&tsyms=USD",
+			expect: func(s *mock.MockService) *gomock.Call {
+				return s.EXPECT().GetMinPrice(gomock.Any(), []string{"BTC"}, []string{"USD"})
+			},
+		},
+		{
+			name:   "avg",
+			target: "/v1/get_avg_rate?fsyms=BTC&tsyms=USD",
+			expect: func(s *mock.MockService) *gomock.Call {
+				return s.EXPECT().GetAvgPrice(gomock.Any(), []string{"BTC"}, []string{"USD"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, service := newServer(t)
+			tt.expect(service).Return(aggregate, nil)
+
+			rec, coins := doRequest(t, server, tt.target)
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, dto.CoinsDTO{{
+				Title:      "BTC",
+				Quote:      "USD",
+				Price:      decimal.RequireFromString("103512.456"),
+				CreateTime: "2025-05-17T12:00:00Z",
+				From:       "2025-05-16T12:00:00Z",
+				To:         "2025-05-17T12:00:00Z",
+			}}, coins)
+		})
+	}
+}
+
+func TestServer_GetLastPriceHandler(t *testing.T) {
+	server, service := newServer(t)
+	service.EXPECT().GetLastPrice(gomock.Any(), []string{"BTC", "ETH"}, nil).
+		Return([]entities.Coin{
+			{Title: "BTC", Quote: "RUB", Price: decimal.RequireFromString("8398290.1"), CreateTime: to},
+			{Title: "ETH", Quote: "RUB", Price: decimal.RequireFromString("196888.49"), CreateTime: to},
+		}, nil)
+
+	rec, coins := doRequest(t, server, "/v1/get_current_rate?fsyms=btc,eth")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, coins, 2)
+	assert.Equal(t, "BTC", coins[0].Title)
+	assert.Equal(t, "2025-05-17T12:00:00Z", coins[0].CreateTime)
+	assert.Empty(t, coins[0].From)
+	assert.Equal(t, "ETH", coins[1].Title)
+}
+
+func TestServer_RangeParams(t *testing.T) {
+	t.Run("window", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetAvgPrice(gomock.Any(), []string{"BTC"}, nil, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ []string, opts ...usecases.Option) ([]entities.Coin, error) {
+				o := &usecases.Options{}
+				for _, opt := range opts {
+					opt(o)
+				}
+				assert.Equal(t, 7*24*time.Hour, o.Window)
+				return []entities.Coin{{Title: "BTC", Quote: "RUB", CreateTime: to, From: from}}, nil
+			})
+
+		rec, _ := doRequest(t, server, "/v1/get_avg_rate?fsyms=BTC&window=7d")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("from and to", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetMaxPrice(gomock.Any(), []string{"BTC"}, nil, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ []string, opts ...usecases.Option) ([]entities.Coin, error) {
+				o := &usecases.Options{}
+				for _, opt := range opts {
+					opt(o)
+				}
+				assert.True(t, from.Equal(o.From))
+				assert.True(t, to.Equal(o.To))
+				return []entities.Coin{{Title: "BTC", Quote: "RUB", CreateTime: to, From: from}}, nil
+			})
+
+		rec, _ := doRequest(t, server, "/v1/get_max_rate?fsyms=BTC&from=2025-05-16T12:00:00Z&to=2025-05-17T12:00:00Z")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("offset is converted to UTC", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetMaxPrice(gomock.Any(), []string{"BTC"}, nil, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ []string, opts ...usecases.Option) ([]entities.Coin, error) {
+				o := &usecases.Options{}
+				for _, opt := range opts {
+					opt(o)
+				}
+				assert.Equal(t, from, o.From)
+				assert.Equal(t, time.UTC, o.From.Location())
+				return []entities.Coin{{Title: "BTC", Quote: "RUB", CreateTime: to, From: from}}, nil
+			})
+
+		rec, _ := doRequest(t, server, "/v1/get_max_rate?fsyms=BTC&from=2025-05-16T15:00:00%2B03:00")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("range is ignored by current rate", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetLastPrice(gomock.Any(), []string{"BTC"}, nil).
+			Return([]entities.Coin{{Title: "BTC", Quote: "RUB", CreateTime: to}}, nil)
+
+		rec, _ := doRequest(t, server, "/v1/get_current_rate?fsyms=BTC&window=bad")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestServer_BadRequest(t *testing.T) {
+	targets := map[string]string{
+		"missing fsyms":    "/v1/get_max_rate",
+		"empty symbol":     "/v1/get_current_rate?fsyms=BTC,,ETH",
+		"invalid window":   "/v1/get_min_rate?fsyms=BTC&window=week",
+		"window with from": "/v1/get_min_rate?fsyms=BTC&window=24h&from=2025-05-16T12:00:00Z",
+		"invalid from":     "/v1/get_avg_rate?fsyms=BTC&from=yesterday",
+		"from after to":    "/v1/get_avg_rate?fsyms=BTC&from=2025-05-17T12:00:00Z&to=2025-05-16T12:00:00Z",
+	}
+
+	for name, target := range targets {
+		t.Run(name, func(t *testing.T) {
+			server, _ := newServer(t)
+
+			rec, _ := doRequest(t, server, target)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestServer_Fallback(t *testing.T) {
+	notFound := errors.Wrap(entities.ErrNotFound, "prices are not stored")
+
+	t.Run("fetches missing titles and repeats the aggregate", func(t *testing.T) {
+		server, service := newServer(t)
+		gomock.InOrder(
+			service.EXPECT().GetMaxPrice(gomock.Any(), []string{"XRP"}, nil).Return(nil, notFound),
+			service.EXPECT().FetchMissing(gomock.Any(), []string{"XRP"}, nil).
+				Return([]entities.Coin{{Title: "XRP", Quote: "RUB", Price: decimal.NewFromInt(1), CreateTime: to}}, nil),
+			service.EXPECT().GetMaxPrice(gomock.Any(), []string{"XRP"}, nil).
+				Return([]entities.Coin{{Title: "XRP", Quote: "RUB", Price: decimal.NewFromInt(1), CreateTime: to, From: to}}, nil),
+		)
+
+		rec, coins := doRequest(t, server, "/v1/get_max_rate?fsyms=XRP")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, coins, 1)
+		assert.Equal(t, "XRP", coins[0].Title)
+	})
+
+	t.Run("external API failure", func(t *testing.T) {
+		server, service := newServer(t)
+		gomock.InOrder(
+			service.EXPECT().GetLastPrice(gomock.Any(), []string{"XRP"}, nil).Return(nil, notFound),
+			service.EXPECT().FetchMissing(gomock.Any(), []string{"XRP"}, nil).Return(nil, entities.ErrGetFunc),
+		)
+
+		rec, _ := doRequest(t, server, "/v1/get_current_rate?fsyms=XRP")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("still missing after fetch", func(t *testing.T) {
+		server, service := newServer(t)
+		gomock.InOrder(
+			service.EXPECT().GetMinPrice(gomock.Any(), []string{"XRP"}, nil, gomock.Any()).Return(nil, notFound),
+			service.EXPECT().FetchMissing(gomock.Any(), []string{"XRP"}, nil).Return(nil, nil),
+			service.EXPECT().GetMinPrice(gomock.Any(), []string{"XRP"}, nil, gomock.Any()).Return(nil, notFound),
+		)
+
+		rec, _ := doRequest(t, server, "/v1/get_min_rate?fsyms=XRP&to=2020-01-01T00:00:00Z")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("empty range isn't fetched", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetMaxPrice(gomock.Any(), []string{"BTC"}, nil, gomock.Any()).Return(nil, nil)
+
+		rec, _ := doRequest(t, server, "/v1/get_max_rate?fsyms=BTC&to=2020-01-01T00:00:00Z")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("invalid params aren't fetched", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetLastPrice(gomock.Any(), []string{"BTC"}, nil).
+			Return(nil, errors.Wrap(entities.ErrInvalidParams, "incorrect parameters"))
+
+		rec, _ := doRequest(t, server, "/v1/get_current_rate?fsyms=BTC")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("storage failure", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetAvgPrice(gomock.Any(), []string{"BTC"}, nil).
+			Return(nil, errors.Wrap(entities.ErrGetFunc, "GetAvgPrice"))
+
+		rec, _ := doRequest(t, server, "/v1/get_avg_rate?fsyms=BTC")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestServer_GetCandlesHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetCandles(gomock.Any(), []string{"BTC"}, []string{"USD"}, 24*time.Hour, from, to).
+			Return([]entities.Candle{
+				{Title: "BTC", Quote: "USD", Start: from, Interval: 24 * time.Hour, Open: decimal.RequireFromString("1.111"), High: decimal.NewFromInt(3), Low: decimal.RequireFromString("0.5"), Close: decimal.NewFromInt(2)},
+			}, nil)
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/v1/candles?fsyms=BTC&tsyms=USD&interval=1d&from=2025-05-16T12:00:00Z&to=2025-05-17T12:00:00Z", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var candles dto.CandlesDTO
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&candles))
+		assert.Equal(t, dto.CandlesDTO{{
+			Title: "BTC",
+			Quote: "USD",
+			Start: "2025-05-16T12:00:00Z",
+			End:   "2025-05-17T12:00:00Z",
+			Open:  decimal.RequireFromString("1.111"),
+			High:  decimal.NewFromInt(3),
+			Low:   decimal.RequireFromString("0.5"),
+			Close: decimal.NewFromInt(2),
+		}}, candles)
+	})
+
+	t.Run("default interval", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetCandles(gomock.Any(), []string{"BTC"}, nil, time.Hour, time.Time{}, time.Time{}).
+			Return(nil, nil)
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/candles?fsyms=BTC", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("unsupported interval", func(t *testing.T) {
+		server, _ := newServer(t)
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/candles?fsyms=BTC&interval=2h", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		server, service := newServer(t)
+		service.EXPECT().GetCandles(gomock.Any(), []string{"BTC"}, nil, time.Minute, gomock.Any(), gomock.Any()).
+			Return(nil, errors.Wrap(entities.ErrInvalidParams, "range exceeds 1000 candles"))
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/candles?fsyms=BTC&interval=1m&window=30d", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
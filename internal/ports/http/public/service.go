@@ -2,14 +2,33 @@ package public
 
 import (
 	"context"
+	"time"
 
 	"currency/internal/entities"
+	"currency/internal/usecases"
 )
 
+//go:generate mockgen -source=service.go -destination=./mocks/service_mock.go -package=mock
 type Service interface {
-	GetLastPrice(ctx context.Context, titles []string) ([]entities.Coin, error)
-	GetMinPrice(ctx context.Context, titles []string) ([]entities.Coin, error)
-	GetMaxPrice(ctx context.Context, titles []string) ([]entities.Coin, error)
-	GetAvgPrice(ctx context.Context, titles []string) ([]entities.Coin, error)
-	GetCoinsFromAPI(ctx context.Context, titles ...string) ([]entities.Coin, error)
+	GetLastPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetMinPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetMaxPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetAvgPrice(ctx context.Context, titles, quotes []string, opts ...usecases.Option) ([]entities.Coin, error)
+	GetCandles(ctx context.Context, titles, quotes []string, interval time.Duration, from, to time.Time) ([]entities.Candle, error)
+	GetCoinsFromAPI(ctx context.Context, titles, quotes []string) ([]entities.Coin, error)
+	FetchMissing(ctx context.Context, titles, quotes []string) ([]entities.Coin, error)
+	GetCoinsAfter(ctx context.Context, id int64, titles, quotes []string) ([]entities.Coin, error)
+	Subscribe(titles, quotes []string) *usecases.Subscription
+	CreateAlert(ctx context.Context, alert entities.Alert) (*entities.Alert, error)
+	GetAlert(ctx context.Context, id int64) (*entities.Alert, error)
+	GetAlerts(ctx context.Context) ([]entities.Alert, error)
+	UpdateAlert(ctx context.Context, alert entities.Alert) (*entities.Alert, error)
+	DeleteAlert(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, alertID int64) ([]entities.Delivery, error)
+	AddSymbol(ctx context.Context, title string) (*entities.Symbol, error)
+	GetSymbols(ctx context.Context) ([]entities.Symbol, error)
+	SetSymbolPaused(ctx context.Context, title string, paused bool) (*entities.Symbol, error)
+	DeleteSymbol(ctx context.Context, title string) error
+	Ready(ctx context.Context) map[string]error
+	Status(ctx context.Context) (*entities.Status, error)
 }
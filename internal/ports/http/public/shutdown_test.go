@@ -0,0 +1,82 @@
+package public_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"currency/internal/logging"
+	"currency/internal/ports/http/public"
+	mock "currency/internal/ports/http/public/mocks"
+	"currency/internal/usecases"
+	usecasesmock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServer_Shutdown checks that Shutdown ends the streams instead of
+// waiting for their clients to leave.
+func TestServer_Shutdown(t *testing.T) {
+	newStreamingServer := func(t *testing.T) (*public.Server, *httptest.Server) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		service, err := usecases.NewService(usecasesmock.NewMockStorage(ctrl), usecasesmock.NewMockClient(ctrl), logging.Nop())
+		require.NoError(t, err)
+		port := mock.NewMockService(ctrl)
+		port.EXPECT().Subscribe(gomock.Any(), gomock.Any()).DoAndReturn(service.Subscribe)
+
+		server, err := public.NewServer(port, "8080", logging.Nop())
+		require.NoError(t, err)
+		testServer := httptest.NewServer(server)
+		t.Cleanup(testServer.Close)
+		return server, testServer
+	}
+
+	t.Run("events", func(t *testing.T) {
+		server, testServer := newStreamingServer(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL+"/v1/events", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.NoError(t, server.Shutdown(ctx))
+
+		_, err = io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		server, testServer := newStreamingServer(t)
+
+		url := "ws" + strings.TrimPrefix(testServer.URL, "http") + "/v1/stream"
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		var msg map[string]interface{}
+		require.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, "subscribed", msg["type"])
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, server.Shutdown(ctx))
+
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), err)
+	})
+}
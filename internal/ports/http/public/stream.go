@@ -0,0 +1,163 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"currency/internal/usecases"
+	"currency/pkg/dto"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// writeWait bounds every write; a client that can't keep up is dropped.
+	writeWait = 10 * time.Second
+	// pongWait is how long a connection may stay silent, pings included.
+	pongWait = 60 * time.Second
+	// pingPeriod must be shorter than pongWait.
+	pingPeriod = pongWait * 9 / 10
+	// maxMessageSize bounds client control messages.
+	maxMessageSize = 4096
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// StreamHandler godoc
+//
+//	@Summary		Stream prices
+//	@Description	WebSocket stream of prices stored by the scheduler. Send {"type":"subscribe","fsyms":["XRP"]} or {"type":"unsubscribe","fsyms":["BTC"]} to change the subscription; prices arrive as {"type":"price","coins":[...]}. Without fsyms every coin is streamed.
+//	@Tags			coins
+//	@Param			fsyms	query		string	false	"Comma-separated list of cryptocurrencies"
+//	@Param			tsyms	query		string	false	"Comma-separated list of quote currencies, all quotes by default"
+//	@Success		101		{object}	dto.StreamMessageDTO
+//	@Failure		400		{object}	map[string]interface{}	"Not a WebSocket handshake"
+//	@Router			/v1/stream [get]
+func (s *Server) StreamHandler(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	var titles []string
+	if fsyms := query.Get("fsyms"); fsyms != "" {
+		titles = strings.Split(strings.ToUpper(fsyms), ",")
+	}
+	quotes := parseQuotes(query.Get("tsyms"))
+
+	conn, err := upgrader.Upgrade(rw, req, nil)
+	if err != nil {
+		// Upgrade has already replied with an error.
+		return
+	}
+	defer conn.Close()
+
+	sub := s.service.Subscribe(titles, quotes)
+	defer sub.Close()
+
+	replies := make(chan dto.StreamMessageDTO, 1)
+	replies <- dto.StreamMessageDTO{Type: "subscribed", Fsyms: sub.Titles(), Tsyms: quotes}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	done := make(chan struct{})
+	go s.readStream(conn, sub, replies, stop, done)
+
+	ping := time.NewTicker(pingPeriod)
+	defer ping.Stop()
+
+	for {
+		var msg dto.StreamMessageDTO
+		select {
+		case <-done:
+			return
+		case <-req.Context().Done():
+			return
+		case <-s.closing:
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down"), time.Now().Add(writeWait))
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			continue
+		case msg = <-replies:
+		case <-sub.Ready():
+			coins := sub.Next()
+			if len(coins) == 0 {
+				continue
+			}
+			msg = dto.StreamMessageDTO{Type: "price", Coins: toCoinsDTO(coins)}
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+// readStream applies subscribe/unsubscribe messages until the connection
+// fails or stays silent for pongWait, then closes done. It gives up on a
+// reply once stop is closed.
+func (s *Server) readStream(conn *websocket.Conn, sub *usecases.Subscription, replies chan<- dto.StreamMessageDTO, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	reply := func(msg dto.StreamMessageDTO) bool {
+		select {
+		case replies <- msg:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var msg dto.StreamMessageDTO
+		if err := json.Unmarshal(data, &msg); err != nil {
+			if !reply(dto.StreamMessageDTO{Type: "error", Error: "malformed message"}) {
+				return
+			}
+			continue
+		}
+
+		titles := make([]string, 0, len(msg.Fsyms))
+		for _, t := range msg.Fsyms {
+			titles = append(titles, strings.ToUpper(t))
+		}
+		quotes := make([]string, 0, len(msg.Tsyms))
+		for _, q := range msg.Tsyms {
+			quotes = append(quotes, strings.ToUpper(q))
+		}
+
+		answer := dto.StreamMessageDTO{Type: "subscribed"}
+		switch msg.Type {
+		case "subscribe":
+			sub.Subscribe(titles, quotes)
+			answer.Fsyms = sub.Titles()
+		case "unsubscribe":
+			sub.Unsubscribe(titles)
+			answer.Fsyms = sub.Titles()
+		default:
+			answer = dto.StreamMessageDTO{Type: "error", Error: "unknown message type: " + msg.Type}
+		}
+		if !reply(answer) {
+			return
+		}
+	}
+}
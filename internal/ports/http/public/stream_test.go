@@ -0,0 +1,97 @@
+package public_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/ports/http/public"
+	mock "currency/internal/ports/http/public/mocks"
+	"currency/internal/usecases"
+	usecasesmock "currency/internal/usecases/mocks"
+	"currency/pkg/dto"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_StreamHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A real usecases.Service provides the subscriptions and publishes what
+	// GetCoinsFromAPI stores.
+	storage, client := usecasesmock.NewMockStorage(ctrl), usecasesmock.NewMockClient(ctrl)
+	storage.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	service, err := usecases.NewService(storage, client, logging.Nop())
+	require.NoError(t, err)
+
+	port := mock.NewMockService(ctrl)
+	port.EXPECT().Subscribe(gomock.Any(), gomock.Any()).DoAndReturn(service.Subscribe)
+
+	server, err := public.NewServer(port, "8080", logging.Nop())
+	require.NoError(t, err)
+	testServer := httptest.NewServer(server)
+	defer testServer.Close()
+
+	url := "ws" + strings.TrimPrefix(testServer.URL, "http") + "/v1/stream?fsyms=btc&tsyms=usd"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var msg dto.StreamMessageDTO
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, dto.StreamMessageDTO{Type: "subscribed", Fsyms: []string{"BTC"}, Tsyms: []string{"USD"}}, msg)
+
+	publish := func(coins ...entities.Coin) {
+		client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+		_, err := service.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+	}
+
+	now := time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+	publish(
+		entities.Coin{Title: "BTC", Quote: "USD", Price: decimal.RequireFromString("103512.4"), CreateTime: now},
+		entities.Coin{Title: "BTC", Quote: "RUB", Price: decimal.RequireFromString("8398290.1"), CreateTime: now},
+		entities.Coin{Title: "ETH", Quote: "USD", Price: decimal.RequireFromString("2540.1"), CreateTime: now},
+	)
+
+	msg = dto.StreamMessageDTO{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, "price", msg.Type)
+	require.Len(t, msg.Coins, 1)
+	assert.Equal(t, "BTC", msg.Coins[0].Title)
+	assert.Equal(t, "103512.4", msg.Coins[0].Price.String())
+
+	require.NoError(t, conn.WriteJSON(dto.StreamMessageDTO{Type: "subscribe", Fsyms: []string{"eth"}}))
+	msg = dto.StreamMessageDTO{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, dto.StreamMessageDTO{Type: "subscribed", Fsyms: []string{"BTC", "ETH"}}, msg)
+
+	publish(entities.Coin{Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(2541), CreateTime: now})
+	msg = dto.StreamMessageDTO{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	require.Len(t, msg.Coins, 1)
+	assert.Equal(t, "ETH", msg.Coins[0].Title)
+
+	require.NoError(t, conn.WriteJSON(dto.StreamMessageDTO{Type: "dance"}))
+	msg = dto.StreamMessageDTO{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, "error", msg.Type)
+}
+
+func TestServer_StreamHandler_NotWebSocket(t *testing.T) {
+	server, service := newServer(t)
+	service.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(0)
+
+	rec, _ := doRequest(t, server, "/v1/stream?fsyms=BTC")
+	assert.Equal(t, 400, rec.Code)
+}
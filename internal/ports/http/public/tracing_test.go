@@ -0,0 +1,75 @@
+package public_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/ports/http/public"
+	"currency/internal/tracing"
+	"currency/internal/usecases"
+	usecasesmock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestServer_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	_, err := tracing.Init(context.Background(), "currency-test", tracing.Config{})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage, client := usecasesmock.NewMockStorage(ctrl), usecasesmock.NewMockClient(ctrl)
+	coins := []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(100), CreateTime: to}}
+	gomock.InOrder(
+		storage.EXPECT().Get(gomock.Any(), []string{"BTC"}, gomock.Any()).Return(nil, entities.ErrNotFound),
+		storage.EXPECT().GetUpdates(gomock.Any()).Return(nil, nil),
+		client.EXPECT().GetCoins(gomock.Any(), []string{"BTC"}, nil).Return(coins, nil),
+		storage.EXPECT().Store(gomock.Any(), coins).Return(nil),
+		storage.EXPECT().Get(gomock.Any(), []string{"BTC"}, gomock.Any()).Return(coins, nil),
+	)
+	service, err := usecases.NewService(storage, client, logging.Nop())
+	require.NoError(t, err)
+	server, err := public.NewServer(service, "8080", logging.Nop())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/get_current_rate?fsyms=BTC", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	spans := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+	require.Len(t, spans["GET /v1/get_current_rate"], 1)
+	require.Len(t, spans["Service.GetLastPrice"], 2)
+	require.Len(t, spans["Service.FetchMissing"], 1)
+	require.Len(t, spans["Service.GetCoinsFromAPI"], 1)
+
+	root := spans["GET /v1/get_current_rate"][0]
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", root.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", root.Parent().SpanID().String())
+	assert.True(t, root.Parent().IsRemote())
+
+	for _, span := range append(spans["Service.GetLastPrice"], spans["Service.FetchMissing"]...) {
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+	}
+	fetch := spans["Service.GetCoinsFromAPI"][0]
+	assert.Equal(t, spans["Service.FetchMissing"][0].SpanContext().SpanID(), fetch.Parent().SpanID())
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: retention.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// RollUpHourly mocks base method.
+func (m *MockStorage) RollUpHourly(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollUpHourly", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollUpHourly indicates an expected call of RollUpHourly.
+func (mr *MockStorageMockRecorder) RollUpHourly(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollUpHourly", reflect.TypeOf((*MockStorage)(nil).RollUpHourly), ctx, before)
+}
+
+// RollUpRaw mocks base method.
+func (m *MockStorage) RollUpRaw(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollUpRaw", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollUpRaw indicates an expected call of RollUpRaw.
+func (mr *MockStorageMockRecorder) RollUpRaw(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollUpRaw", reflect.TypeOf((*MockStorage)(nil).RollUpRaw), ctx, before)
+}
+
+// MockLeader is a mock of Leader interface.
+type MockLeader struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaderMockRecorder
+}
+
+// MockLeaderMockRecorder is the mock recorder for MockLeader.
+type MockLeaderMockRecorder struct {
+	mock *MockLeader
+}
+
+// NewMockLeader creates a new mock instance.
+func NewMockLeader(ctrl *gomock.Controller) *MockLeader {
+	mock := &MockLeader{ctrl: ctrl}
+	mock.recorder = &MockLeaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeader) EXPECT() *MockLeaderMockRecorder {
+	return m.recorder
+}
+
+// IsLeader mocks base method.
+func (m *MockLeader) IsLeader() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLeader")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsLeader indicates an expected call of IsLeader.
+func (mr *MockLeaderMockRecorder) IsLeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLeader", reflect.TypeOf((*MockLeader)(nil).IsLeader))
+}
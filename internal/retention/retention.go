@@ -0,0 +1,158 @@
+// Package retention downsamples the price history. Raw prices older than Raw
+// are rolled up into hourly buckets and hourly buckets older than Hourly
+// into daily ones; the rolled up rows are deleted.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/metrics"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultRaw      = 30 * 24 * time.Hour
+	DefaultHourly   = 365 * 24 * time.Hour
+	DefaultInterval = time.Hour
+)
+
+// Config is retention in config.yaml.
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Raw is how long raw prices are kept before they are rolled up by hour.
+	Raw time.Duration `mapstructure:"raw"`
+	// Hourly is how long hourly rollups are kept before they are rolled up
+	// by day. Daily rollups are kept forever.
+	Hourly   time.Duration `mapstructure:"hourly"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+//go:generate mockgen -source=retention.go -destination=./mocks/storage_mock.go -package=mock
+type Storage interface {
+	// RollUpRaw moves the raw prices created before the hour of before into
+	// hourly rollups and returns how many it moved.
+	RollUpRaw(ctx context.Context, before time.Time) (int64, error)
+	// RollUpHourly moves the hourly rollups before the day of before into
+	// daily rollups and returns how many it moved.
+	RollUpHourly(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Leader tells whether this instance should downsample.
+type Leader interface {
+	IsLeader() bool
+}
+
+// Retainer downsamples on start and then every interval until Stop.
+type Retainer struct {
+	storage  Storage
+	logger   *slog.Logger
+	raw      time.Duration
+	hourly   time.Duration
+	interval time.Duration
+	// leader is nil unless the replicas elect the one that ingests prices.
+	leader Leader
+
+	stopping chan struct{}
+	done     chan struct{}
+}
+
+func New(storage Storage, logger *slog.Logger, cfg Config) (*Retainer, error) {
+	if storage == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "storage is nil")
+	}
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	if cfg.Raw < 0 || cfg.Hourly < 0 || cfg.Interval < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams,
+			fmt.Sprintf("raw: %s, hourly: %s, interval: %s", cfg.Raw, cfg.Hourly, cfg.Interval))
+	}
+	if cfg.Raw == 0 {
+		cfg.Raw = DefaultRaw
+	}
+	if cfg.Hourly == 0 {
+		cfg.Hourly = DefaultHourly
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+	// часовые агрегаты строятся из сырых цен, поэтому живут дольше них.
+	if cfg.Hourly < cfg.Raw {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("hourly %s is shorter than raw %s", cfg.Hourly, cfg.Raw))
+	}
+
+	return &Retainer{
+		storage:  storage,
+		logger:   logger,
+		raw:      cfg.Raw,
+		hourly:   cfg.Hourly,
+		interval: cfg.Interval,
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// SetLeader makes only the leader downsample.
+func (r *Retainer) SetLeader(leader Leader) {
+	r.leader = leader
+}
+
+// Run downsamples every interval until Stop.
+func (r *Retainer) Run() error {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if r.leader == nil || r.leader.IsLeader() {
+			r.RollUp(context.Background())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.stopping:
+			return nil
+		}
+	}
+}
+
+// RollUp rolls up raw prices first, so hourly rollups they produce are
+// rolled up further in the same run once they are old enough.
+func (r *Retainer) RollUp(ctx context.Context) {
+	now := time.Now()
+
+	raw, err := r.storage.RollUpRaw(ctx, now.Add(-r.raw))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "roll up raw prices failed", slog.Any("error", err))
+		return
+	}
+	metrics.RolledUp.WithLabelValues("raw").Add(float64(raw))
+
+	hourly, err := r.storage.RollUpHourly(ctx, now.Add(-r.hourly))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "roll up hourly prices failed", slog.Any("error", err))
+		return
+	}
+	metrics.RolledUp.WithLabelValues("hourly").Add(float64(hourly))
+
+	if raw > 0 || hourly > 0 {
+		r.logger.InfoContext(ctx, "prices rolled up", slog.Int64("raw", raw), slog.Int64("hourly", hourly))
+	}
+}
+
+// Stop waits for the roll up in progress, if any.
+func (r *Retainer) Stop(ctx context.Context) error {
+	close(r.stopping)
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(entities.ErrInternalServer, "roll up is still running")
+	}
+}
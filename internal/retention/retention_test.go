@@ -0,0 +1,136 @@
+package retention_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/retention"
+	mock "currency/internal/retention/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storage := mock.NewMockStorage(ctrl)
+
+	tests := []struct {
+		name    string
+		storage retention.Storage
+		cfg     retention.Config
+		wantErr bool
+	}{
+		{name: "defaults", storage: storage},
+		{name: "nil storage", wantErr: true},
+		{name: "negative raw", storage: storage, cfg: retention.Config{Raw: -time.Hour}, wantErr: true},
+		{name: "negative interval", storage: storage, cfg: retention.Config{Interval: -time.Hour}, wantErr: true},
+		{name: "hourly shorter than raw", storage: storage, cfg: retention.Config{Raw: 48 * time.Hour, Hourly: 24 * time.Hour}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := retention.New(tt.storage, logging.Nop(), tt.cfg)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entities.ErrInvalidParams)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err := retention.New(storage, nil, retention.Config{})
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+}
+
+func TestRetainer_RollUp(t *testing.T) {
+	cfg := retention.Config{Raw: 24 * time.Hour, Hourly: 7 * 24 * time.Hour}
+
+	t.Run("raw then hourly", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storage := mock.NewMockStorage(ctrl)
+		now := time.Now()
+		gomock.InOrder(
+			storage.EXPECT().RollUpRaw(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+				assert.WithinDuration(t, now.Add(-cfg.Raw), before, time.Second)
+				return 1440, nil
+			}),
+			storage.EXPECT().RollUpHourly(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+				assert.WithinDuration(t, now.Add(-cfg.Hourly), before, time.Second)
+				return 24, nil
+			}),
+		)
+
+		r, err := retention.New(storage, logging.Nop(), cfg)
+		require.NoError(t, err)
+		r.RollUp(context.Background())
+	})
+
+	t.Run("raw failure skips hourly", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storage := mock.NewMockStorage(ctrl)
+		storage.EXPECT().RollUpRaw(gomock.Any(), gomock.Any()).Return(int64(0), errors.Wrap(entities.ErrInternalServer, "Unable to roll up raw prices"))
+
+		r, err := retention.New(storage, logging.Nop(), cfg)
+		require.NoError(t, err)
+		r.RollUp(context.Background())
+	})
+}
+
+type leader bool
+
+func (l *leader) IsLeader() bool { return bool(*l) }
+
+func TestRetainer_Run(t *testing.T) {
+	t.Run("follower doesn't roll up", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		r, err := retention.New(mock.NewMockStorage(ctrl), logging.Nop(), retention.Config{Interval: time.Millisecond})
+		require.NoError(t, err)
+		follower := leader(false)
+		r.SetLeader(&follower)
+
+		go func() { _ = r.Run() }()
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, r.Stop(context.Background()))
+	})
+
+	t.Run("rolls up on start and every interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storage := mock.NewMockStorage(ctrl)
+		rolled := make(chan struct{}, 10)
+		storage.EXPECT().RollUpRaw(gomock.Any(), gomock.Any()).Return(int64(0), nil).MinTimes(2)
+		storage.EXPECT().RollUpHourly(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, time.Time) (int64, error) {
+			select {
+			case rolled <- struct{}{}:
+			default:
+			}
+			return 0, nil
+		}).MinTimes(2)
+
+		r, err := retention.New(storage, logging.Nop(), retention.Config{Interval: 5 * time.Millisecond})
+		require.NoError(t, err)
+
+		go func() { _ = r.Run() }()
+		for i := 0; i < 2; i++ {
+			select {
+			case <-rolled:
+			case <-time.After(time.Second):
+				t.Fatal("no roll up")
+			}
+		}
+		require.NoError(t, r.Stop(context.Background()))
+	})
+}
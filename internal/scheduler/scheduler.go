@@ -0,0 +1,345 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+)
+
+const DefaultSchedule = "1m"
+
+// Config is a schedule, a duration such as 30s or a cron expression such as
+// "*/5 * * * *" or "@hourly", for every tracked symbol but those in
+// Overrides, which have their own.
+type Config struct {
+	Schedule string `mapstructure:"schedule"`
+	// Jitter is the upper bound of a random delay before every scheduled
+	// update, so instances don't call the providers at once.
+	Jitter    time.Duration `mapstructure:"jitter"`
+	Overrides []Override    `mapstructure:"overrides"`
+}
+
+type Override struct {
+	Symbols  []string `mapstructure:"symbols"`
+	Schedule string   `mapstructure:"schedule"`
+}
+
+//go:generate mockgen -source=scheduler.go -destination=./mocks/service_mock.go -package=mock
+type Service interface {
+	GetSymbols(ctx context.Context) ([]entities.Symbol, error)
+	GetCoinsFromAPI(ctx context.Context, titles, quotes []string) ([]entities.Coin, error)
+}
+
+// Leader tells whether this instance should ingest prices.
+type Leader interface {
+	IsLeader() bool
+}
+
+// Status is the state of the scheduler and the next run of every job.
+type Status struct {
+	Paused bool
+	Jobs   []Job
+}
+
+// Job updates Symbols on Schedule; nil Symbols is every symbol without an
+// override.
+type Job struct {
+	Symbols  []string
+	Schedule string
+	Next     time.Time
+}
+
+// Scheduler updates the prices of the tracked symbols once on start and
+// then on the configured schedules.
+type Scheduler struct {
+	cron    *cron.Cron
+	service Service
+	logger  *slog.Logger
+	quotes  []string
+	jitter  time.Duration
+	jobs    []Job
+	ids     []cron.EntryID
+	// overridden are the symbols left out of the default job.
+	overridden map[string]bool
+	paused     atomic.Bool
+	// leader is nil unless the replicas elect the one that ingests prices.
+	leader Leader
+	// refreshes are the updates started by Elected; mu keeps them from
+	// starting once Stop waits for them.
+	mu        sync.Mutex
+	stopped   bool
+	refreshes sync.WaitGroup
+	// started is closed once Run has started cron or gave up on it because
+	// stopping was closed first.
+	started  chan struct{}
+	stopping chan struct{}
+}
+
+func New(service Service, logger *slog.Logger, cfg Config, quotes []string) (*Scheduler, error) {
+	if service == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "service is nil")
+	}
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	if cfg.Jitter < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("jitter: %s", cfg.Jitter))
+	}
+	if cfg.Schedule == "" {
+		cfg.Schedule = DefaultSchedule
+	}
+
+	cronLogger := cronLogger{logger}
+	s := &Scheduler{
+		cron:       cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger))),
+		service:    service,
+		logger:     logger,
+		quotes:     quotes,
+		jitter:     cfg.Jitter,
+		overridden: make(map[string]bool),
+		started:    make(chan struct{}),
+		stopping:   make(chan struct{}),
+	}
+
+	for _, override := range cfg.Overrides {
+		symbols := make([]string, 0, len(override.Symbols))
+		for _, title := range override.Symbols {
+			symbol, err := entities.NewSymbol(title)
+			if err != nil {
+				return nil, errors.Wrap(err, "override")
+			}
+			if s.overridden[symbol.Title] {
+				return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("%s has several overrides", symbol.Title))
+			}
+			s.overridden[symbol.Title] = true
+			symbols = append(symbols, symbol.Title)
+		}
+		if len(symbols) == 0 {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("override %q has no symbols", override.Schedule))
+		}
+		if err := s.addJob(symbols, override.Schedule); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.addJob(nil, cfg.Schedule); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Scheduler) addJob(symbols []string, spec string) error {
+	schedule, err := parseSchedule(spec)
+	if err != nil {
+		return err
+	}
+	job := Job{Symbols: symbols, Schedule: spec}
+	id := s.cron.Schedule(schedule, cron.FuncJob(func() { s.update(job) }))
+	s.jobs = append(s.jobs, job)
+	s.ids = append(s.ids, id)
+	return nil
+}
+
+// parseSchedule reads a duration or a cron expression.
+func parseSchedule(spec string) (cron.Schedule, error) {
+	if d, err := time.ParseDuration(spec); err == nil {
+		// cron не умеет интервалы меньше секунды.
+		if d < time.Second {
+			return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("schedule %s is shorter than 1s", spec))
+		}
+		return cron.Every(d), nil
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("schedule %q: %s", spec, err))
+	}
+	return schedule, nil
+}
+
+// update refreshes the symbols of job after the jitter unless the scheduler
+// is paused.
+func (s *Scheduler) update(job Job) {
+	if s.paused.Load() || !s.isLeader() {
+		return
+	}
+	if s.jitter > 0 {
+		select {
+		case <-time.After(rand.N(s.jitter)):
+		case <-s.stopping:
+			return
+		}
+	}
+
+	ctx := context.Background()
+	titles, err := s.titles(ctx, job)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "price update failed", slog.String("schedule", job.Schedule), slog.Any("error", err))
+		return
+	}
+	if len(titles) == 0 {
+		return
+	}
+	if _, err := s.service.GetCoinsFromAPI(ctx, titles, s.quotes); err != nil {
+		s.logger.ErrorContext(ctx, "price update failed", slog.String("schedule", job.Schedule), slog.Any("error", err))
+	}
+}
+
+// titles returns the tracked symbols of job that aren't paused.
+func (s *Scheduler) titles(ctx context.Context, job Job) ([]string, error) {
+	symbols, err := s.service.GetSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var titles []string
+	for _, symbol := range symbols {
+		if symbol.Paused {
+			continue
+		}
+		if (job.Symbols == nil && !s.overridden[symbol.Title]) || contains(job.Symbols, symbol.Title) {
+			titles = append(titles, symbol.Title)
+		}
+	}
+	return titles, nil
+}
+
+func contains(titles []string, title string) bool {
+	for _, t := range titles {
+		if t == title {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLeader makes the scheduled updates run only on the leader, which makes
+// the initial update once elected instead of on Run.
+func (s *Scheduler) SetLeader(leader Leader) {
+	s.leader = leader
+}
+
+func (s *Scheduler) isLeader() bool {
+	return s.leader == nil || s.leader.IsLeader()
+}
+
+// Elected updates every tracked symbol in the background, so a new leader
+// doesn't wait for the next run to catch up.
+func (s *Scheduler) Elected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+
+	s.refreshes.Add(1)
+	go func() {
+		defer s.refreshes.Done()
+		ctx := context.Background()
+		if _, err := s.Refresh(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "price update failed", slog.Any("error", err))
+		}
+	}()
+}
+
+// Refresh updates every tracked symbol now, even while the scheduler is
+// paused.
+func (s *Scheduler) Refresh(ctx context.Context) ([]entities.Coin, error) {
+	return s.service.GetCoinsFromAPI(ctx, nil, s.quotes)
+}
+
+// Pause skips the scheduled updates until Resume. The initial update and
+// Refresh aren't affected.
+func (s *Scheduler) Pause() {
+	s.paused.Store(true)
+}
+
+func (s *Scheduler) Resume() {
+	s.paused.Store(false)
+}
+
+func (s *Scheduler) Status() Status {
+	next := make(map[cron.EntryID]time.Time)
+	for _, entry := range s.cron.Entries() {
+		next[entry.ID] = entry.Next
+	}
+
+	status := Status{Paused: s.paused.Load(), Jobs: make([]Job, 0, len(s.jobs))}
+	for i, job := range s.jobs {
+		job.Next = next[s.ids[i]]
+		status.Jobs = append(status.Jobs, job)
+	}
+	return status
+}
+
+// Run makes the initial update unless there is a Leader and starts cron in
+// the background.
+func (s *Scheduler) Run() error {
+	defer close(s.started)
+
+	if s.leader == nil {
+		ctx := context.Background()
+		if _, err := s.Refresh(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "price update failed", slog.Any("error", err))
+		}
+	}
+	select {
+	case <-s.stopping:
+	default:
+		s.cron.Start()
+	}
+	return nil
+}
+
+// Stop waits for the updates in progress, if any, and stops cron.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	close(s.stopping)
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+
+	select {
+	case <-s.started:
+	case <-ctx.Done():
+		return errors.Wrap(entities.ErrInternalServer, "initial price update is still running")
+	}
+
+	select {
+	case <-s.cron.Stop().Done():
+	case <-ctx.Done():
+		return errors.Wrap(entities.ErrInternalServer, "price update is still running")
+	}
+
+	refreshed := make(chan struct{})
+	go func() {
+		s.refreshes.Wait()
+		close(refreshed)
+	}()
+	select {
+	case <-refreshed:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(entities.ErrInternalServer, "price update is still running")
+	}
+}
+
+// cronLogger reports cron events, e.g. skipped overlapping runs, to slog.
+type cronLogger struct {
+	logger *slog.Logger
+}
+
+func (l cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug("cron: "+msg, keysAndValues...)
+}
+
+func (l cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.logger.Error("cron: "+msg, append([]interface{}{slog.Any("error", err)}, keysAndValues...)...)
+}
@@ -0,0 +1,189 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	mock "currency/internal/scheduler/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var quotes = []string{"USD"}
+
+func newScheduler(t *testing.T, cfg Config) (*Scheduler, *mock.MockService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	service := mock.NewMockService(ctrl)
+	s, err := New(service, logging.Nop(), cfg, quotes)
+	require.NoError(t, err)
+	return s, service
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mock.NewMockService(ctrl)
+
+	_, err := New(nil, logging.Nop(), Config{}, quotes)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+	_, err = New(service, nil, Config{}, quotes)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+
+	for name, cfg := range map[string]Config{
+		"invalid schedule":  {Schedule: "sometimes"},
+		"shorter than 1s":   {Schedule: "500ms"},
+		"negative jitter":   {Jitter: -time.Second},
+		"invalid symbol":    {Overrides: []Override{{Symbols: []string{"BTC/USD"}, Schedule: "10s"}}},
+		"no symbols":        {Overrides: []Override{{Schedule: "10s"}}},
+		"several overrides": {Overrides: []Override{{Symbols: []string{"BTC"}, Schedule: "10s"}, {Symbols: []string{"btc"}, Schedule: "1h"}}},
+		"invalid override":  {Overrides: []Override{{Symbols: []string{"BTC"}, Schedule: "* * *"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(service, logging.Nop(), cfg, quotes)
+			assert.ErrorIs(t, err, entities.ErrInvalidParams)
+		})
+	}
+
+	for _, schedule := range []string{"", "10s", "1h", "*/5 * * * *", "@hourly", "@every 30s"} {
+		_, err := New(service, logging.Nop(), Config{Schedule: schedule}, quotes)
+		assert.NoError(t, err, schedule)
+	}
+}
+
+func TestScheduler_update(t *testing.T) {
+	symbols := []entities.Symbol{{Title: "BTC"}, {Title: "DOGE"}, {Title: "ETH"}, {Title: "XRP", Paused: true}}
+	cfg := Config{Overrides: []Override{{Symbols: []string{"btc", "eth", "xrp"}, Schedule: "10s"}}}
+
+	t.Run("override job", func(t *testing.T) {
+		s, service := newScheduler(t, cfg)
+		gomock.InOrder(
+			service.EXPECT().GetSymbols(gomock.Any()).Return(symbols, nil),
+			service.EXPECT().GetCoinsFromAPI(gomock.Any(), []string{"BTC", "ETH"}, quotes).Return(nil, nil),
+		)
+		s.update(s.jobs[0])
+	})
+
+	t.Run("default job skips overridden symbols", func(t *testing.T) {
+		s, service := newScheduler(t, cfg)
+		gomock.InOrder(
+			service.EXPECT().GetSymbols(gomock.Any()).Return(symbols, nil),
+			service.EXPECT().GetCoinsFromAPI(gomock.Any(), []string{"DOGE"}, quotes).Return(nil, nil),
+		)
+		s.update(s.jobs[1])
+	})
+
+	t.Run("no tracked symbols", func(t *testing.T) {
+		s, service := newScheduler(t, cfg)
+		service.EXPECT().GetSymbols(gomock.Any()).Return([]entities.Symbol{{Title: "DOGE"}}, nil)
+		s.update(s.jobs[0])
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		s, service := newScheduler(t, cfg)
+		service.EXPECT().GetSymbols(gomock.Any()).Return(nil, errors.Wrap(entities.ErrInternalServer, "Unable to get symbols"))
+		s.update(s.jobs[1])
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		s, _ := newScheduler(t, cfg)
+		s.Pause()
+		s.update(s.jobs[0])
+		assert.True(t, s.Status().Paused)
+
+		s.Resume()
+		assert.False(t, s.Status().Paused)
+	})
+
+	t.Run("stop interrupts jitter", func(t *testing.T) {
+		s, _ := newScheduler(t, Config{Jitter: time.Hour})
+		close(s.stopping)
+
+		done := make(chan struct{})
+		go func() {
+			s.update(s.jobs[0])
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("update waited for the jitter after stop")
+		}
+	})
+}
+
+func TestScheduler_Refresh(t *testing.T) {
+	s, service := newScheduler(t, Config{})
+	s.Pause()
+
+	coins := []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(100000)}}
+	service.EXPECT().GetCoinsFromAPI(gomock.Any(), nil, quotes).Return(coins, nil)
+
+	got, err := s.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, coins, got)
+}
+
+func TestScheduler_Run(t *testing.T) {
+	s, service := newScheduler(t, Config{Schedule: "1h", Overrides: []Override{{Symbols: []string{"BTC"}, Schedule: "10s"}}})
+	service.EXPECT().GetCoinsFromAPI(gomock.Any(), nil, quotes).Return(nil, nil)
+
+	status := s.Status()
+	require.Len(t, status.Jobs, 2)
+	assert.Equal(t, []string{"BTC"}, status.Jobs[0].Symbols)
+	assert.Nil(t, status.Jobs[1].Symbols)
+	assert.True(t, status.Jobs[0].Next.IsZero())
+
+	require.NoError(t, s.Run())
+	status = s.Status()
+	assert.WithinDuration(t, time.Now().Add(10*time.Second), status.Jobs[0].Next, 2*time.Second)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), status.Jobs[1].Next, 2*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+}
+
+type leader bool
+
+func (l *leader) IsLeader() bool { return bool(*l) }
+
+func TestScheduler_Leader(t *testing.T) {
+	t.Run("follower skips updates", func(t *testing.T) {
+		s, _ := newScheduler(t, Config{Schedule: "1h"})
+		follower := leader(false)
+		s.SetLeader(&follower)
+
+		s.update(s.jobs[0])
+		require.NoError(t, s.Run())
+		require.NoError(t, s.Stop(context.Background()))
+	})
+
+	t.Run("leader updates once elected", func(t *testing.T) {
+		s, service := newScheduler(t, Config{Schedule: "1h"})
+		elected := leader(true)
+		s.SetLeader(&elected)
+
+		refreshed := make(chan struct{})
+		service.EXPECT().GetCoinsFromAPI(gomock.Any(), nil, quotes).DoAndReturn(func(context.Context, []string, []string) ([]entities.Coin, error) {
+			close(refreshed)
+			return nil, nil
+		})
+
+		require.NoError(t, s.Run())
+		s.Elected()
+		<-refreshed
+		require.NoError(t, s.Stop(context.Background()))
+
+		// no updates after Stop
+		s.Elected()
+	})
+}
@@ -0,0 +1,93 @@
+// Package tracing sets up OpenTelemetry: W3C trace context propagation and
+// span export over OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"currency/internal/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config is tracing in config.yaml.
+type Config struct {
+	// Endpoint is the OTLP/HTTP collector URL, e.g. http://collector:4318.
+	// Spans are not exported when it is empty.
+	Endpoint string `mapstructure:"endpoint"`
+	// SampleRatio is the share of root traces sampled, every trace when zero;
+	// traces started upstream follow the caller's decision.
+	SampleRatio float64 `mapstructure:"sampleRatio"`
+}
+
+// Init installs the global propagator and, if cfg.Endpoint is set, a tracer
+// provider exporting to it. shutdown flushes the pending spans.
+func Init(ctx context.Context, serviceName string, cfg Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, err.Error())
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// End records *err on span, if any, and ends it. Use it deferred with a
+// named error result.
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// Symbols are the titles and quotes of a request as span attributes.
+func Symbols(titles, quotes []string) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attribute.StringSlice("currency.titles", titles),
+		attribute.StringSlice("currency.quotes", quotes),
+	)
+}
+
+// RouteName renames the server span of the request after the matched chi
+// route. It must be installed with chi's Use inside the otelhttp handler.
+func RouteName(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(rw, req)
+
+		rctx := chi.RouteContext(req.Context())
+		if rctx == nil || rctx.RoutePattern() == "" {
+			return
+		}
+		span := trace.SpanFromContext(req.Context())
+		span.SetName(req.Method + " " + rctx.RoutePattern())
+		span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+	})
+}
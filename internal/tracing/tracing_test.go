@@ -0,0 +1,103 @@
+package tracing_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"currency/internal/tracing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is an OTLP/HTTP trace receiver.
+type collector struct {
+	mu    sync.Mutex
+	spans map[string]*tracepb.Span
+	// services are the service.name resource attributes received.
+	services []string
+}
+
+func (c *collector) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/v1/traces" {
+		http.NotFound(rw, req)
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var export collectortrace.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &export); err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range export.ResourceSpans {
+		for _, attr := range rs.GetResource().GetAttributes() {
+			if attr.Key == "service.name" {
+				c.services = append(c.services, attr.GetValue().GetStringValue())
+			}
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				c.spans[span.Name] = span
+			}
+		}
+	}
+
+	resp, _ := proto.Marshal(&collectortrace.ExportTraceServiceResponse{})
+	rw.Header().Set("Content-Type", "application/x-protobuf")
+	rw.Write(resp)
+}
+
+func TestInit(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	c := &collector{spans: make(map[string]*tracepb.Span)}
+	testServer := httptest.NewServer(c)
+	defer testServer.Close()
+
+	shutdown, err := tracing.Init(context.Background(), "currency-test", tracing.Config{Endpoint: testServer.URL})
+	require.NoError(t, err)
+
+	tracer := otel.Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	func() (err error) {
+		_, child := tracer.Start(ctx, "child")
+		defer tracing.End(child, &err)
+		return errors.New("child failed")
+	}()
+	parent.End()
+
+	require.NoError(t, shutdown(context.Background()))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.Contains(t, c.services, "currency-test")
+	require.Contains(t, c.spans, "parent")
+	require.Contains(t, c.spans, "child")
+	assert.Equal(t, c.spans["parent"].TraceId, c.spans["child"].TraceId)
+	assert.Equal(t, c.spans["parent"].SpanId, c.spans["child"].ParentSpanId)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, c.spans["child"].GetStatus().GetCode())
+	assert.Equal(t, "child failed", c.spans["child"].GetStatus().GetMessage())
+}
+
+func TestInit_NoEndpoint(t *testing.T) {
+	shutdown, err := tracing.Init(context.Background(), "currency-test", tracing.Config{})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
@@ -0,0 +1,298 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+// MaxDeliveries limits the delivery log returned by GetDeliveries.
+const MaxDeliveries = 100
+
+// AlertConfig is alerts in config.yaml.
+type AlertConfig struct {
+	// MaxAttempts is the number of delivery attempts per fired alert.
+	MaxAttempts int `mapstructure:"maxAttempts"`
+	// BaseDelay is the pause after the first failed attempt, doubled after
+	// every next one.
+	BaseDelay time.Duration `mapstructure:"baseDelay"`
+}
+
+// DefaultAlertConfig is used for every zero field of an AlertConfig.
+var DefaultAlertConfig = AlertConfig{
+	MaxAttempts: 5,
+	BaseDelay:   time.Second,
+}
+
+type alerting struct {
+	storage  AlertStorage
+	notifier Notifier
+	config   AlertConfig
+	logger   *slog.Logger
+	// mu serializes evaluation so concurrent ingestions can't fire an alert twice.
+	mu sync.Mutex
+	// deliveries tracks the deliver goroutines; closing stops their retries.
+	deliveries sync.WaitGroup
+	closing    chan struct{}
+	closed     bool
+}
+
+// SetAlerts enables alerts: every batch stored by GetCoinsFromAPI is checked
+// against the stored alerts and the fired ones are delivered by notifier in
+// the background.
+func (s *Service) SetAlerts(storage AlertStorage, notifier Notifier, cfg AlertConfig) error {
+	if storage == nil {
+		return errors.Wrap(entities.ErrInvalidParams, "alert storage is nil")
+	}
+	if notifier == nil {
+		return errors.Wrap(entities.ErrInvalidParams, "notifier is nil")
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = DefaultAlertConfig.MaxAttempts
+	}
+	if cfg.BaseDelay == 0 {
+		cfg.BaseDelay = DefaultAlertConfig.BaseDelay
+	}
+	s.alerts = &alerting{storage: storage, notifier: notifier, config: cfg, logger: s.logger, closing: make(chan struct{})}
+	return nil
+}
+
+var errAlertsDisabled = errors.Wrap(entities.ErrInternalServer, "alerts are disabled")
+
+// CreateAlert validates and stores alert. A secret is generated when alert has
+// none; it is returned only here.
+func (s *Service) CreateAlert(ctx context.Context, alert entities.Alert) (*entities.Alert, error) {
+	if s.alerts == nil {
+		return nil, errAlertsDisabled
+	}
+	created, err := entities.NewAlert(alert.Title, alert.Quote, alert.Condition, alert.Threshold, alert.Cooldown, alert.WebhookURL, alert.Secret)
+	if err != nil {
+		return nil, err
+	}
+	if created.Secret == "" {
+		if created.Secret, err = newSecret(); err != nil {
+			return nil, errors.Wrap(entities.ErrInternalServer, "CreateAlert")
+		}
+	}
+
+	if err := s.alerts.storage.CreateAlert(ctx, created); err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "CreateAlert")
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetAlert(ctx context.Context, id int64) (*entities.Alert, error) {
+	if s.alerts == nil {
+		return nil, errAlertsDisabled
+	}
+	alert, err := s.alerts.storage.GetAlert(ctx, id)
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, errors.Wrap(entities.ErrNotFound, "GetAlert")
+	}
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "GetAlert")
+	}
+
+	return alert, nil
+}
+
+func (s *Service) GetAlerts(ctx context.Context) ([]entities.Alert, error) {
+	if s.alerts == nil {
+		return nil, errAlertsDisabled
+	}
+	alerts, err := s.alerts.storage.GetAlerts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "GetAlerts")
+	}
+
+	return alerts, nil
+}
+
+// UpdateAlert replaces the rule of the alert with alert.ID. An empty secret
+// keeps the current one; changing the condition resets the reference price.
+func (s *Service) UpdateAlert(ctx context.Context, alert entities.Alert) (*entities.Alert, error) {
+	current, err := s.GetAlert(ctx, alert.ID)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Secret == "" {
+		alert.Secret = current.Secret
+	}
+	updated, err := entities.NewAlert(alert.Title, alert.Quote, alert.Condition, alert.Threshold, alert.Cooldown, alert.WebhookURL, alert.Secret)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID, updated.CreateTime, updated.LastFiredAt = current.ID, current.CreateTime, current.LastFiredAt
+	if updated.Condition == current.Condition {
+		updated.LastPrice = current.LastPrice
+	}
+
+	err = s.alerts.storage.UpdateAlert(ctx, updated)
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, errors.Wrap(entities.ErrNotFound, "UpdateAlert")
+	}
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "UpdateAlert")
+	}
+
+	return updated, nil
+}
+
+func (s *Service) DeleteAlert(ctx context.Context, id int64) error {
+	if s.alerts == nil {
+		return errAlertsDisabled
+	}
+	err := s.alerts.storage.DeleteAlert(ctx, id)
+	if errors.Is(err, entities.ErrNotFound) {
+		return errors.Wrap(entities.ErrNotFound, "DeleteAlert")
+	}
+	if err != nil {
+		return errors.Wrap(entities.ErrGetFunc, "DeleteAlert")
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the latest MaxDeliveries delivery attempts of the
+// alert, newest first.
+func (s *Service) GetDeliveries(ctx context.Context, alertID int64) ([]entities.Delivery, error) {
+	if _, err := s.GetAlert(ctx, alertID); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.alerts.storage.GetDeliveries(ctx, alertID, MaxDeliveries)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "GetDeliveries")
+	}
+
+	return deliveries, nil
+}
+
+// evaluateAlerts fires every alert triggered by coins. Failures are logged:
+// alerts must not fail the ingestion.
+func (s *Service) evaluateAlerts(ctx context.Context, coins []entities.Coin) {
+	a := s.alerts
+	if a == nil || len(coins) == 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return
+	}
+
+	alerts, err := a.storage.GetAlerts(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "get alerts failed", slog.Any("error", err))
+		return
+	}
+
+	prices := make(map[pair]entities.Coin, len(coins))
+	for _, coin := range coins {
+		prices[pair{coin.Title, coin.Quote}] = coin
+	}
+
+	now := time.Now()
+	for _, alert := range alerts {
+		coin, ok := prices[pair{alert.Title, alert.Quote}]
+		if !ok {
+			continue
+		}
+		switch {
+		case alert.Triggered(coin.Price, now):
+			alert.LastPrice, alert.LastFiredAt = coin.Price, now
+			// Не отправляем, если не сохранили: иначе алерт сработает снова без cooldown.
+			if err := a.storage.SetAlertState(ctx, alert.ID, alert.LastPrice, alert.LastFiredAt); err != nil {
+				s.logger.ErrorContext(ctx, "save alert state failed", slog.Int64("alert_id", alert.ID), slog.Any("error", err))
+				continue
+			}
+			a.deliveries.Add(1)
+			go func(alert entities.Alert, coin entities.Coin) {
+				defer a.deliveries.Done()
+				a.deliver(context.WithoutCancel(ctx), alert, coin)
+			}(alert, coin)
+		case alert.Condition != entities.AlertPercentChange && !alert.LastPrice.Equal(coin.Price),
+			alert.Condition == entities.AlertPercentChange && alert.LastPrice.IsZero():
+			// above и below помнят последнюю цену, чтобы срабатывать только на пересечении.
+			if err := a.storage.SetAlertState(ctx, alert.ID, coin.Price, alert.LastFiredAt); err != nil {
+				s.logger.ErrorContext(ctx, "save alert state failed", slog.Int64("alert_id", alert.ID), slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// deliver notifies about a fired alert, retrying with exponential backoff,
+// and logs every attempt. Client errors other than 408 and 429 are final.
+func (a *alerting) deliver(ctx context.Context, alert entities.Alert, coin entities.Coin) {
+	logger := a.logger.With(slog.Int64("alert_id", alert.ID))
+	delay := a.config.BaseDelay
+	for attempt := 1; attempt <= a.config.MaxAttempts; attempt++ {
+		status, err := a.notifier.Notify(ctx, alert, coin)
+
+		delivery := &entities.Delivery{AlertID: alert.ID, Attempt: attempt, StatusCode: status, CreateTime: time.Now()}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if err := a.storage.StoreDelivery(ctx, delivery); err != nil {
+			logger.ErrorContext(ctx, "store delivery failed", slog.Any("error", err))
+		}
+
+		if err == nil {
+			logger.InfoContext(ctx, "alert delivered", slog.Int("attempt", attempt))
+			return
+		}
+		logger.WarnContext(ctx, "alert delivery failed", slog.Int("attempt", attempt), slog.Int("status", status), slog.Any("error", err))
+		if status >= 400 && status < 500 && status != http.StatusRequestTimeout && status != http.StatusTooManyRequests {
+			return
+		}
+		if attempt < a.config.MaxAttempts {
+			select {
+			case <-time.After(delay):
+			case <-a.closing:
+				logger.WarnContext(ctx, "alert delivery abandoned on shutdown", slog.Int("attempt", attempt))
+				return
+			}
+			delay *= 2
+		}
+	}
+}
+
+// shutdown stops evaluating alerts and retrying deliveries, then waits for
+// the deliveries in flight until ctx is done.
+func (a *alerting) shutdown(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.closing)
+	}
+	a.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		a.deliveries.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(entities.ErrInternalServer, "alert deliveries are still in flight")
+	}
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,423 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type alertFields struct {
+	storage  *mock.MockStorage
+	client   *mock.MockClient
+	alerts   *mock.MockAlertStorage
+	notifier *mock.MockNotifier
+}
+
+func newAlertService(t *testing.T) (*usecases.Service, alertFields) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	f := alertFields{
+		storage:  mock.NewMockStorage(ctrl),
+		client:   mock.NewMockClient(ctrl),
+		alerts:   mock.NewMockAlertStorage(ctrl),
+		notifier: mock.NewMockNotifier(ctrl),
+	}
+	s, err := usecases.NewService(f.storage, f.client, logging.Nop())
+	require.NoError(t, err)
+	require.NoError(t, s.SetAlerts(f.alerts, f.notifier, usecases.AlertConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	return s, f
+}
+
+func TestService_CreateAlert(t *testing.T) {
+	alert := entities.Alert{
+		Title:      "BTC",
+		Quote:      "USD",
+		Condition:  entities.AlertAbove,
+		Threshold:  decimal.NewFromInt(100000),
+		Cooldown:   time.Hour,
+		WebhookURL: "https://example.com/hook",
+	}
+
+	t.Run("alerts disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		s, err := usecases.NewService(mock.NewMockStorage(ctrl), mock.NewMockClient(ctrl), logging.Nop())
+		require.NoError(t, err)
+
+		_, err = s.CreateAlert(context.Background(), alert)
+		assert.ErrorIs(t, err, entities.ErrInternalServer)
+	})
+
+	t.Run("invalid alert", func(t *testing.T) {
+		s, _ := newAlertService(t)
+
+		invalid := alert
+		invalid.WebhookURL = "ftp://example.com"
+		_, err := s.CreateAlert(context.Background(), invalid)
+		assert.ErrorIs(t, err, entities.ErrInvalidParams)
+
+		invalid = alert
+		invalid.Condition = "crosses"
+		_, err = s.CreateAlert(context.Background(), invalid)
+		assert.ErrorIs(t, err, entities.ErrInvalidParams)
+	})
+
+	t.Run("internal webhook host", func(t *testing.T) {
+		s, _ := newAlertService(t)
+
+		for _, url := range []string{
+			"http://localhost:8080/hook",
+			"http://127.0.0.1/hook",
+			"http://10.0.0.5/hook",
+			"http://192.168.1.1/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]/hook",
+			"http://0.0.0.0/hook",
+		} {
+			internal := alert
+			internal.WebhookURL = url
+			_, err := s.CreateAlert(context.Background(), internal)
+			assert.ErrorIs(t, err, entities.ErrInvalidParams, url)
+		}
+	})
+
+	t.Run("generates secret", func(t *testing.T) {
+		s, f := newAlertService(t)
+		f.alerts.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *entities.Alert) error {
+			a.ID = 1
+			return nil
+		})
+
+		created, err := s.CreateAlert(context.Background(), alert)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), created.ID)
+		assert.Len(t, created.Secret, 64)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		s, f := newAlertService(t)
+		f.alerts.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(errors.New("insert failed"))
+
+		_, err := s.CreateAlert(context.Background(), alert)
+		assert.ErrorIs(t, err, entities.ErrGetFunc)
+	})
+}
+
+func TestService_UpdateAlert(t *testing.T) {
+	current := &entities.Alert{
+		ID:          1,
+		Title:       "BTC",
+		Quote:       "USD",
+		Condition:   entities.AlertPercentChange,
+		Threshold:   decimal.NewFromInt(5),
+		WebhookURL:  "https://example.com/hook",
+		Secret:      "secret",
+		LastPrice:   decimal.NewFromInt(100),
+		LastFiredAt: time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		s, f := newAlertService(t)
+		f.alerts.EXPECT().GetAlert(gomock.Any(), int64(2)).Return(nil, entities.ErrNotFound)
+
+		_, err := s.UpdateAlert(context.Background(), entities.Alert{ID: 2})
+		assert.ErrorIs(t, err, entities.ErrNotFound)
+	})
+
+	t.Run("keeps secret and state", func(t *testing.T) {
+		s, f := newAlertService(t)
+		f.alerts.EXPECT().GetAlert(gomock.Any(), int64(1)).Return(current, nil)
+		f.alerts.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return(nil)
+
+		updated, err := s.UpdateAlert(context.Background(), entities.Alert{
+			ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertPercentChange, Threshold: decimal.NewFromInt(10), WebhookURL: "https://example.com/new",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "secret", updated.Secret)
+		assert.Equal(t, "10", updated.Threshold.String())
+		assert.Equal(t, "100", updated.LastPrice.String())
+		assert.Equal(t, current.LastFiredAt, updated.LastFiredAt)
+	})
+
+	t.Run("condition change resets reference price", func(t *testing.T) {
+		s, f := newAlertService(t)
+		f.alerts.EXPECT().GetAlert(gomock.Any(), int64(1)).Return(current, nil)
+		f.alerts.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return(nil)
+
+		updated, err := s.UpdateAlert(context.Background(), entities.Alert{
+			ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertBelow, Threshold: decimal.NewFromInt(90), WebhookURL: "https://example.com/hook",
+		})
+		require.NoError(t, err)
+		assert.Zero(t, updated.LastPrice)
+	})
+}
+
+func TestService_GetCoinsFromAPI_Alerts(t *testing.T) {
+	coins := []entities.Coin{
+		{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(105000)},
+		{Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(2500)},
+	}
+
+	tests := []struct {
+		name   string
+		alert  entities.Alert
+		status []int
+		// wantState is the price SetAlertState is called with, zero if it isn't.
+		wantState int64
+		// wantAttempts is the number of delivery attempts.
+		wantAttempts int
+	}{
+		{
+			name:         "above fires",
+			alert:        entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000)},
+			status:       []int{200},
+			wantState:    105000,
+			wantAttempts: 1,
+		},
+		{
+			name:      "below doesn't fire",
+			alert:     entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertBelow, Threshold: decimal.NewFromInt(100000)},
+			wantState: 105000,
+		},
+		{
+			name: "above stays above",
+			alert: entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000),
+				LastPrice: decimal.NewFromInt(104000)},
+			wantState: 105000,
+		},
+		{
+			name: "above crosses",
+			alert: entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000),
+				LastPrice: decimal.NewFromInt(99000)},
+			status:       []int{200},
+			wantState:    105000,
+			wantAttempts: 1,
+		},
+		{
+			name: "below crosses",
+			alert: entities.Alert{ID: 1, Title: "ETH", Quote: "USD", Condition: entities.AlertBelow, Threshold: decimal.NewFromInt(2600),
+				LastPrice: decimal.NewFromInt(2700)},
+			status:       []int{200},
+			wantState:    2500,
+			wantAttempts: 1,
+		},
+		{
+			name: "unchanged price isn't saved",
+			alert: entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000),
+				LastPrice: decimal.NewFromInt(105000)},
+		},
+		{
+			name:  "other quote doesn't fire",
+			alert: entities.Alert{ID: 1, Title: "BTC", Quote: "EUR", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(1)},
+		},
+		{
+			name: "cooldown",
+			alert: entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000),
+				Cooldown: time.Hour, LastFiredAt: time.Now().Add(-time.Minute)},
+			wantState: 105000,
+		},
+		{
+			name: "cooldown elapsed",
+			alert: entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000),
+				Cooldown: time.Hour, LastFiredAt: time.Now().Add(-2 * time.Hour)},
+			status:       []int{204},
+			wantState:    105000,
+			wantAttempts: 1,
+		},
+		{
+			name:      "percent change sets reference price",
+			alert:     entities.Alert{ID: 1, Title: "ETH", Quote: "USD", Condition: entities.AlertPercentChange, Threshold: decimal.NewFromInt(5)},
+			wantState: 2500,
+		},
+		{
+			name:         "percent change fires on fall",
+			alert:        entities.Alert{ID: 1, Title: "ETH", Quote: "USD", Condition: entities.AlertPercentChange, Threshold: decimal.NewFromInt(5), LastPrice: decimal.NewFromInt(2700)},
+			status:       []int{200},
+			wantState:    2500,
+			wantAttempts: 1,
+		},
+		{
+			name:  "percent change below threshold",
+			alert: entities.Alert{ID: 1, Title: "ETH", Quote: "USD", Condition: entities.AlertPercentChange, Threshold: decimal.NewFromInt(5), LastPrice: decimal.NewFromInt(2550)},
+		},
+		{
+			name:         "retries server errors",
+			alert:        entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000)},
+			status:       []int{500, 0, 200},
+			wantState:    105000,
+			wantAttempts: 3,
+		},
+		{
+			name:         "gives up after max attempts",
+			alert:        entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000)},
+			status:       []int{503, 503, 503},
+			wantState:    105000,
+			wantAttempts: 3,
+		},
+		{
+			name:         "client error is final",
+			alert:        entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000)},
+			status:       []int{404},
+			wantState:    105000,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, f := newAlertService(t)
+			f.client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+			f.storage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+			f.alerts.EXPECT().GetAlerts(gomock.Any()).Return([]entities.Alert{tt.alert}, nil)
+			if tt.wantState != 0 {
+				f.alerts.EXPECT().SetAlertState(gomock.Any(), tt.alert.ID, decimal.NewFromInt(tt.wantState), gomock.Any()).Return(nil)
+			}
+
+			delivered := make(chan *entities.Delivery, len(tt.status))
+			for _, status := range tt.status[:tt.wantAttempts] {
+				var err error
+				if status < 200 || status > 299 {
+					err = errors.New("delivery failed")
+				}
+				f.notifier.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any()).Return(status, err)
+				f.alerts.EXPECT().StoreDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *entities.Delivery) error {
+					delivered <- d
+					return nil
+				})
+			}
+
+			_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC", "ETH"}, nil)
+			require.NoError(t, err)
+
+			for attempt := 1; attempt <= tt.wantAttempts; attempt++ {
+				select {
+				case d := <-delivered:
+					assert.Equal(t, tt.alert.ID, d.AlertID)
+					assert.Equal(t, attempt, d.Attempt)
+					assert.Equal(t, tt.status[attempt-1], d.StatusCode)
+				case <-time.After(time.Second):
+					t.Fatalf("attempt %d wasn't delivered", attempt)
+				}
+			}
+		})
+	}
+}
+
+func TestService_GetCoinsFromAPI_AlertFiresOnce(t *testing.T) {
+	s, f := newAlertService(t)
+
+	// alert хранит состояние между пачками, как хранилище.
+	alert := entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000)}
+	f.alerts.EXPECT().GetAlerts(gomock.Any()).DoAndReturn(func(context.Context) ([]entities.Alert, error) {
+		return []entities.Alert{alert}, nil
+	}).Times(2)
+	f.alerts.EXPECT().SetAlertState(gomock.Any(), alert.ID, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int64, price decimal.Decimal, firedAt time.Time) error {
+			alert.LastPrice, alert.LastFiredAt = price, firedAt
+			return nil
+		}).AnyTimes()
+
+	delivered := make(chan struct{}, 2)
+	f.notifier.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any()).Return(200, nil)
+	f.alerts.EXPECT().StoreDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *entities.Delivery) error {
+		delivered <- struct{}{}
+		return nil
+	})
+
+	for _, price := range []int64{105000, 106000} {
+		coins := []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(price)}}
+		f.client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+		f.storage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+
+		_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Shutdown(ctx))
+	assert.Len(t, delivered, 1)
+	assert.Equal(t, "106000", alert.LastPrice.String())
+}
+
+func TestService_Shutdown(t *testing.T) {
+	coins := []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(105000)}}
+	alert := entities.Alert{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100000)}
+
+	t.Run("abandons retries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storage, client := mock.NewMockStorage(ctrl), mock.NewMockClient(ctrl)
+		alerts, notifier := mock.NewMockAlertStorage(ctrl), mock.NewMockNotifier(ctrl)
+		s, err := usecases.NewService(storage, client, logging.Nop())
+		require.NoError(t, err)
+		require.NoError(t, s.SetAlerts(alerts, notifier, usecases.AlertConfig{MaxAttempts: 5, BaseDelay: time.Hour}))
+
+		client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+		storage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+		alerts.EXPECT().GetAlerts(gomock.Any()).Return([]entities.Alert{alert}, nil)
+		alerts.EXPECT().SetAlertState(gomock.Any(), alert.ID, decimal.NewFromInt(105000), gomock.Any()).Return(nil)
+		delivered := make(chan struct{})
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any()).Return(503, errors.New("delivery failed"))
+		alerts.EXPECT().StoreDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *entities.Delivery) error {
+			close(delivered)
+			return nil
+		})
+
+		_, err = s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+		<-delivered
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, s.Shutdown(ctx))
+	})
+
+	t.Run("waits for deliveries in flight", func(t *testing.T) {
+		s, f := newAlertService(t)
+		f.client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+		f.storage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+		f.alerts.EXPECT().GetAlerts(gomock.Any()).Return([]entities.Alert{alert}, nil)
+		f.alerts.EXPECT().SetAlertState(gomock.Any(), alert.ID, decimal.NewFromInt(105000), gomock.Any()).Return(nil)
+
+		notifying, release := make(chan struct{}), make(chan struct{})
+		f.notifier.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, entities.Alert, entities.Coin) (int, error) {
+			close(notifying)
+			<-release
+			return 200, nil
+		})
+		f.alerts.EXPECT().StoreDelivery(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+		<-notifying
+
+		expired, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, s.Shutdown(expired), entities.ErrInternalServer)
+
+		close(release)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, s.Shutdown(ctx))
+
+		// Nothing is evaluated after Shutdown.
+		f.client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+		f.storage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+		_, err = s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+	})
+}
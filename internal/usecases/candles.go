@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/tracing"
+
+	"github.com/pkg/errors"
+)
+
+// MaxCandles bounds the number of buckets per coin a single GetCandles call
+// may span.
+const MaxCandles = 1000
+
+var candleIntervals = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// ParseInterval parses a candle interval: 1m, 5m, 1h or 1d.
+func ParseInterval(s string) (time.Duration, error) {
+	interval, ok := candleIntervals[s]
+	if !ok {
+		return 0, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("interval: %s", s))
+	}
+	return interval, nil
+}
+
+// candleOrigin aligns candle buckets as date_bin with TIMESTAMP '2000-01-01'
+// does in postgres.
+var candleOrigin = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// CandleStart returns the start of the candle of interval containing t, so
+// the storages without date_bin bucket prices as postgres does.
+func CandleStart(t time.Time, interval time.Duration) time.Time {
+	d := t.UTC().Sub(candleOrigin)
+	n := d / interval
+	if d%interval < 0 {
+		n--
+	}
+	return candleOrigin.Add(n * interval)
+}
+
+// GetCandles returns one candle per interval bucket per coin and quote for
+// prices created in [from, to]. A zero to means now, a zero from means
+// MaxCandles intervals before to.
+func (s *Service) GetCandles(ctx context.Context, titles, quotes []string, interval time.Duration, from, to time.Time) (_ []entities.Candle, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetCandles", tracing.Symbols(titles, quotes))
+	defer tracing.End(span, &err)
+
+	valid := false
+	for _, i := range candleIntervals {
+		valid = valid || i == interval
+	}
+	if !valid {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("interval: %s", interval))
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-MaxCandles * interval)
+	}
+	if from.After(to) {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "from is after to")
+	}
+	if to.Sub(from)/interval > MaxCandles {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("range exceeds %d candles", MaxCandles))
+	}
+
+	candles, err := s.storage.GetCandles(ctx, titles, interval, WithRange(from, to), WithQuotes(quotes...))
+	if errors.Is(err, entities.ErrInvalidParams) {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "incorrect parameters")
+	}
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "GetCandles")
+	}
+
+	return candles, nil
+}
@@ -0,0 +1,133 @@
+package usecases_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func TestParseInterval(t *testing.T) {
+	for s, want := range map[string]time.Duration{"1m": time.Minute, "5m": 5 * time.Minute, "1h": time.Hour, "1d": 24 * time.Hour} {
+		got, err := usecases.ParseInterval(s)
+		if err != nil || got != want {
+			t.Errorf("ParseInterval(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+
+	if _, err := usecases.ParseInterval("2h"); !errors.Is(err, entities.ErrInvalidParams) {
+		t.Errorf("ParseInterval(\"2h\") error = %v, want ErrInvalidParams", err)
+	}
+}
+
+func TestCandleStart(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{time.Date(2025, 5, 17, 12, 34, 56, 0, time.UTC), 5 * time.Minute, time.Date(2025, 5, 17, 12, 30, 0, 0, time.UTC)},
+		{time.Date(2025, 5, 17, 12, 30, 0, 0, time.UTC), 5 * time.Minute, time.Date(2025, 5, 17, 12, 30, 0, 0, time.UTC)},
+		{time.Date(2025, 5, 17, 23, 59, 0, 0, time.FixedZone("MSK", 3*3600)), 24 * time.Hour, time.Date(2025, 5, 17, 0, 0, 0, 0, time.UTC)},
+		// до начала отсчета бакеты тоже выровнены вниз.
+		{time.Date(1999, 12, 31, 23, 59, 0, 0, time.UTC), time.Hour, time.Date(1999, 12, 31, 23, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := usecases.CandleStart(tt.t, tt.interval); !got.Equal(tt.want) {
+			t.Errorf("CandleStart(%v, %v) = %v, want %v", tt.t, tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestService_GetCandles(t *testing.T) {
+	to := time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+	candles := []entities.Candle{{Title: "BTC", Quote: "USD", Start: to.Add(-time.Hour), Interval: time.Hour, Open: decimal.NewFromInt(1), High: decimal.NewFromInt(3), Low: decimal.NewFromInt(1), Close: decimal.NewFromInt(2)}}
+
+	type args struct {
+		interval time.Duration
+		from     time.Time
+		to       time.Time
+	}
+	tests := []struct {
+		name    string
+		prepare func(s *mock.MockStorage)
+		args    args
+		wantErr error
+		want    []entities.Candle
+	}{
+		{
+			name:    "GetCandles() failed - unsupported interval",
+			args:    args{interval: 2 * time.Hour, to: to},
+			wantErr: entities.ErrInvalidParams,
+		},
+		{
+			name:    "GetCandles() failed - from is after to",
+			args:    args{interval: time.Hour, from: to, to: to.Add(-time.Hour)},
+			wantErr: entities.ErrInvalidParams,
+		},
+		{
+			name:    "GetCandles() failed - too many candles",
+			args:    args{interval: time.Minute, from: to.Add(-30 * 24 * time.Hour), to: to},
+			wantErr: entities.ErrInvalidParams,
+		},
+		{
+			name: "GetCandles() failed - storage error",
+			args: args{interval: time.Hour, to: to},
+			prepare: func(s *mock.MockStorage) {
+				s.EXPECT().GetCandles(gomock.Any(), []string{"BTC"}, time.Hour, gomock.Any(), gomock.Any()).
+					Return(nil, entities.ErrInternalServer)
+			},
+			wantErr: entities.ErrGetFunc,
+		},
+		{
+			name: "GetCandles() success - default from",
+			args: args{interval: time.Hour, to: to},
+			prepare: func(s *mock.MockStorage) {
+				s.EXPECT().GetCandles(gomock.Any(), []string{"BTC"}, time.Hour, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ []string, _ time.Duration, opts ...usecases.Option) ([]entities.Candle, error) {
+						o := &usecases.Options{}
+						for _, opt := range opts {
+							opt(o)
+						}
+						if !o.From.Equal(to.Add(-usecases.MaxCandles*time.Hour)) || !o.To.Equal(to) {
+							t.Errorf("GetCandles() range = %v - %v", o.From, o.To)
+						}
+						return candles, nil
+					})
+			},
+			want: candles,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := mock.NewMockStorage(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(storage)
+			}
+
+			s, _ := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+
+			got, err := s.GetCandles(context.Background(), []string{"BTC"}, nil, tt.args.interval, tt.args.from, tt.args.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCandles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCandles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
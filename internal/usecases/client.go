@@ -7,5 +7,5 @@ import (
 
 //go:generate mockgen -source=client.go -destination=./mocks/client_mock.go -package=mock
 type Client interface {
-	GetCoins(ctx context.Context, titles []string) ([]entities.Coin, error)
+	GetCoins(ctx context.Context, titles []string, quotes []string) ([]entities.Coin, error)
 }
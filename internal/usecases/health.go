@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+// Readiness checks reported by Ready.
+const (
+	CheckStorage  = "storage"
+	CheckUpstream = "upstream"
+)
+
+// HealthConfig is health in config.yaml.
+type HealthConfig struct {
+	// Staleness is how long the service stays ready after the last
+	// successful upstream fetch.
+	Staleness time.Duration `mapstructure:"staleness"`
+}
+
+var DefaultHealthConfig = HealthConfig{Staleness: 5 * time.Minute}
+
+// SetHealth configures Ready and Status. Zero fields keep their defaults.
+func (s *Service) SetHealth(cfg HealthConfig) {
+	if cfg.Staleness == 0 {
+		cfg.Staleness = DefaultHealthConfig.Staleness
+	}
+	s.health = cfg
+}
+
+// SetLeader tells Ready and Status which instance ingests prices.
+func (s *Service) SetLeader(leader Leader) {
+	s.leader = leader
+}
+
+// Ready runs the readiness checks and returns their results by name, nil
+// for a passed check. The service is ready when the storage answers and
+// GetCoinsFromAPI succeeded within the staleness window. Followers don't
+// fetch, so they skip the upstream check.
+func (s *Service) Ready(ctx context.Context) map[string]error {
+	checks := make(map[string]error, 2)
+
+	checks[CheckStorage] = s.storage.Ping(ctx)
+
+	switch last := s.LastFetch(); {
+	case s.leader != nil && !s.leader.IsLeader():
+		checks[CheckUpstream] = nil
+	case last.IsZero():
+		checks[CheckUpstream] = errors.Wrap(entities.ErrInternalServer, "no successful fetch yet")
+	case time.Since(last) > s.health.Staleness:
+		checks[CheckUpstream] = errors.Wrap(entities.ErrInternalServer,
+			fmt.Sprintf("last successful fetch at %s", last.UTC().Format(time.RFC3339)))
+	default:
+		checks[CheckUpstream] = nil
+	}
+
+	return checks
+}
+
+// LastFetch returns the time GetCoinsFromAPI last stored prices, zero if it
+// never did.
+func (s *Service) LastFetch() time.Time {
+	nanos := s.lastFetch.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// Status reports the last update of every stored pair.
+func (s *Service) Status(ctx context.Context) (*entities.Status, error) {
+	symbols, err := s.storage.GetUpdates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "Status")
+	}
+
+	now := time.Now()
+	for i := range symbols {
+		symbols[i].Stale = now.Sub(symbols[i].UpdateTime) > s.health.Staleness
+	}
+
+	status := &entities.Status{LastFetch: s.LastFetch(), Symbols: symbols, Leader: true}
+	if s.leader != nil {
+		status.Instance = s.leader.Instance()
+		status.Leader = s.leader.IsLeader()
+		// Статус полезен и без лидера, поэтому ошибку только логируем.
+		leader, err := s.leader.Leader(ctx)
+		if err != nil {
+			s.logger.WarnContext(ctx, "get leader failed", slog.Any("error", err))
+		}
+		status.LeaderInstance = leader
+	}
+	return status, nil
+}
@@ -0,0 +1,158 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Ready(t *testing.T) {
+	tests := []struct {
+		name      string
+		staleness time.Duration
+		fetch     bool
+		pingErr   error
+		wantErr   []string
+	}{
+		{name: "no fetch yet", wantErr: []string{usecases.CheckUpstream}},
+		{name: "ready", fetch: true},
+		{name: "stale", staleness: time.Nanosecond, fetch: true, wantErr: []string{usecases.CheckUpstream}},
+		{
+			name:    "storage down",
+			fetch:   true,
+			pingErr: errors.Wrap(entities.ErrInternalServer, "Could not connect to pool"),
+			wantErr: []string{usecases.CheckStorage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage, client := mock.NewMockStorage(ctrl), mock.NewMockClient(ctrl)
+			s, err := usecases.NewService(storage, client, logging.Nop())
+			require.NoError(t, err)
+			s.SetHealth(usecases.HealthConfig{Staleness: tt.staleness})
+
+			if tt.fetch {
+				client.EXPECT().GetCoins(gomock.Any(), []string{"BTC"}, nil).Return([]entities.Coin{{Title: "BTC"}}, nil)
+				storage.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+				_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+				require.NoError(t, err)
+				time.Sleep(time.Millisecond)
+			}
+			storage.EXPECT().Ping(gomock.Any()).Return(tt.pingErr)
+
+			checks := s.Ready(context.Background())
+			require.Len(t, checks, 2)
+			for name, err := range checks {
+				if assert.Contains(t, []string{usecases.CheckStorage, usecases.CheckUpstream}, name) {
+					assert.Equal(t, hasCheck(tt.wantErr, name), err != nil, name)
+				}
+			}
+		})
+	}
+}
+
+func hasCheck(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestService_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mock.NewMockStorage(ctrl)
+	s, err := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+	require.NoError(t, err)
+	s.SetHealth(usecases.HealthConfig{Staleness: time.Hour})
+
+	t.Run("marks stale pairs", func(t *testing.T) {
+		now := time.Now()
+		storage.EXPECT().GetUpdates(gomock.Any()).Return([]entities.SymbolStatus{
+			{Title: "BTC", Quote: "USD", UpdateTime: now.Add(-time.Minute)},
+			{Title: "ETH", Quote: "USD", UpdateTime: now.Add(-2 * time.Hour)},
+		}, nil)
+
+		status, err := s.Status(context.Background())
+		require.NoError(t, err)
+		assert.True(t, status.LastFetch.IsZero())
+		assert.Equal(t, []entities.SymbolStatus{
+			{Title: "BTC", Quote: "USD", UpdateTime: now.Add(-time.Minute)},
+			{Title: "ETH", Quote: "USD", UpdateTime: now.Add(-2 * time.Hour), Stale: true},
+		}, status.Symbols)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storage.EXPECT().GetUpdates(gomock.Any()).Return(nil, errors.Wrap(entities.ErrInternalServer, "Unable to get updates"))
+
+		_, err := s.Status(context.Background())
+		assert.ErrorIs(t, err, entities.ErrGetFunc)
+	})
+}
+
+func TestService_Leader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage, leader := mock.NewMockStorage(ctrl), mock.NewMockLeader(ctrl)
+	s, err := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+	require.NoError(t, err)
+	s.SetLeader(leader)
+
+	t.Run("follower is ready without fetching", func(t *testing.T) {
+		leader.EXPECT().IsLeader().Return(false)
+		storage.EXPECT().Ping(gomock.Any()).Return(nil)
+
+		for name, err := range s.Ready(context.Background()) {
+			assert.NoError(t, err, name)
+		}
+	})
+
+	t.Run("leader must fetch", func(t *testing.T) {
+		leader.EXPECT().IsLeader().Return(true)
+		storage.EXPECT().Ping(gomock.Any()).Return(nil)
+
+		assert.Error(t, s.Ready(context.Background())[usecases.CheckUpstream])
+	})
+
+	t.Run("status", func(t *testing.T) {
+		storage.EXPECT().GetUpdates(gomock.Any()).Return(nil, nil)
+		leader.EXPECT().Instance().Return("coinapp-2")
+		leader.EXPECT().IsLeader().Return(false)
+		leader.EXPECT().Leader(gomock.Any()).Return("coinapp-1", nil)
+
+		status, err := s.Status(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "coinapp-2", status.Instance)
+		assert.False(t, status.Leader)
+		assert.Equal(t, "coinapp-1", status.LeaderInstance)
+	})
+
+	t.Run("status without leader", func(t *testing.T) {
+		storage.EXPECT().GetUpdates(gomock.Any()).Return(nil, nil)
+		leader.EXPECT().Instance().Return("coinapp-2")
+		leader.EXPECT().IsLeader().Return(false)
+		leader.EXPECT().Leader(gomock.Any()).Return("", errors.Wrap(entities.ErrInternalServer, "Unable to get holder of lock 1"))
+
+		status, err := s.Status(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, status.LeaderInstance)
+	})
+}
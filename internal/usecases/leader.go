@@ -0,0 +1,12 @@
+package usecases
+
+import "context"
+
+//go:generate mockgen -source=leader.go -destination=./mocks/leader_mock.go -package=mock
+type Leader interface {
+	// IsLeader tells whether this instance ingests prices.
+	IsLeader() bool
+	Instance() string
+	// Leader returns the instance that ingests prices, empty if none does.
+	Leader(ctx context.Context) (string, error)
+}
@@ -36,16 +36,16 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // GetCoins mocks base method.
-func (m *MockClient) GetCoins(ctx context.Context, titles []string) ([]entities.Coin, error) {
+func (m *MockClient) GetCoins(ctx context.Context, titles, quotes []string) ([]entities.Coin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCoins", ctx, titles)
+	ret := m.ctrl.Call(m, "GetCoins", ctx, titles, quotes)
 	ret0, _ := ret[0].([]entities.Coin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCoins indicates an expected call of GetCoins.
-func (mr *MockClientMockRecorder) GetCoins(ctx, titles interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetCoins(ctx, titles, quotes interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoins", reflect.TypeOf((*MockClient)(nil).GetCoins), ctx, titles)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoins", reflect.TypeOf((*MockClient)(nil).GetCoins), ctx, titles, quotes)
 }
@@ -9,8 +9,10 @@ import (
 	entities "currency/internal/entities"
 	usecases "currency/internal/usecases"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
 )
 
 // MockStorage is a mock of Storage interface.
@@ -36,6 +38,34 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AddSymbol mocks base method.
+func (m *MockStorage) AddSymbol(ctx context.Context, symbol *entities.Symbol) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSymbol", ctx, symbol)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSymbol indicates an expected call of AddSymbol.
+func (mr *MockStorageMockRecorder) AddSymbol(ctx, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSymbol", reflect.TypeOf((*MockStorage)(nil).AddSymbol), ctx, symbol)
+}
+
+// DeleteSymbol mocks base method.
+func (m *MockStorage) DeleteSymbol(ctx context.Context, title string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSymbol", ctx, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSymbol indicates an expected call of DeleteSymbol.
+func (mr *MockStorageMockRecorder) DeleteSymbol(ctx, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSymbol", reflect.TypeOf((*MockStorage)(nil).DeleteSymbol), ctx, title)
+}
+
 // Get mocks base method.
 func (m *MockStorage) Get(ctx context.Context, titles []string, opt ...usecases.Option) ([]entities.Coin, error) {
 	m.ctrl.T.Helper()
@@ -56,19 +86,118 @@ func (mr *MockStorageMockRecorder) Get(ctx, titles interface{}, opt ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStorage)(nil).Get), varargs...)
 }
 
-// GetTitles mocks base method.
-func (m *MockStorage) GetTitles(ctx context.Context) ([]string, error) {
+// GetAfter mocks base method.
+func (m *MockStorage) GetAfter(ctx context.Context, id int64, titles []string, limit int, opt ...usecases.Option) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, id, titles, limit}
+	for _, a := range opt {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAfter", varargs...)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAfter indicates an expected call of GetAfter.
+func (mr *MockStorageMockRecorder) GetAfter(ctx, id, titles, limit interface{}, opt ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, id, titles, limit}, opt...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAfter", reflect.TypeOf((*MockStorage)(nil).GetAfter), varargs...)
+}
+
+// GetCandles mocks base method.
+func (m *MockStorage) GetCandles(ctx context.Context, titles []string, interval time.Duration, opt ...usecases.Option) ([]entities.Candle, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, titles, interval}
+	for _, a := range opt {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCandles", varargs...)
+	ret0, _ := ret[0].([]entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockStorageMockRecorder) GetCandles(ctx, titles, interval interface{}, opt ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, titles, interval}, opt...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockStorage)(nil).GetCandles), varargs...)
+}
+
+// GetSymbols mocks base method.
+func (m *MockStorage) GetSymbols(ctx context.Context) ([]entities.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbols", ctx)
+	ret0, _ := ret[0].([]entities.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSymbols indicates an expected call of GetSymbols.
+func (mr *MockStorageMockRecorder) GetSymbols(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbols", reflect.TypeOf((*MockStorage)(nil).GetSymbols), ctx)
+}
+
+// GetUpdates mocks base method.
+func (m *MockStorage) GetUpdates(ctx context.Context) ([]entities.SymbolStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTitles", ctx)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "GetUpdates", ctx)
+	ret0, _ := ret[0].([]entities.SymbolStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTitles indicates an expected call of GetTitles.
-func (mr *MockStorageMockRecorder) GetTitles(ctx interface{}) *gomock.Call {
+// GetUpdates indicates an expected call of GetUpdates.
+func (mr *MockStorageMockRecorder) GetUpdates(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTitles", reflect.TypeOf((*MockStorage)(nil).GetTitles), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdates", reflect.TypeOf((*MockStorage)(nil).GetUpdates), ctx)
+}
+
+// LastID mocks base method.
+func (m *MockStorage) LastID(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastID", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastID indicates an expected call of LastID.
+func (mr *MockStorageMockRecorder) LastID(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastID", reflect.TypeOf((*MockStorage)(nil).LastID), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockStorage) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockStorageMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockStorage)(nil).Ping), ctx)
+}
+
+// SetSymbolPaused mocks base method.
+func (m *MockStorage) SetSymbolPaused(ctx context.Context, title string, paused bool) (*entities.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSymbolPaused", ctx, title, paused)
+	ret0, _ := ret[0].(*entities.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSymbolPaused indicates an expected call of SetSymbolPaused.
+func (mr *MockStorageMockRecorder) SetSymbolPaused(ctx, title, paused interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSymbolPaused", reflect.TypeOf((*MockStorage)(nil).SetSymbolPaused), ctx, title, paused)
 }
 
 // Store mocks base method.
@@ -84,3 +213,141 @@ func (mr *MockStorageMockRecorder) Store(ctx, coins interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockStorage)(nil).Store), ctx, coins)
 }
+
+// MockAlertStorage is a mock of AlertStorage interface.
+type MockAlertStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertStorageMockRecorder
+}
+
+// MockAlertStorageMockRecorder is the mock recorder for MockAlertStorage.
+type MockAlertStorageMockRecorder struct {
+	mock *MockAlertStorage
+}
+
+// NewMockAlertStorage creates a new mock instance.
+func NewMockAlertStorage(ctrl *gomock.Controller) *MockAlertStorage {
+	mock := &MockAlertStorage{ctrl: ctrl}
+	mock.recorder = &MockAlertStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertStorage) EXPECT() *MockAlertStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateAlert mocks base method.
+func (m *MockAlertStorage) CreateAlert(ctx context.Context, alert *entities.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlert", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAlert indicates an expected call of CreateAlert.
+func (mr *MockAlertStorageMockRecorder) CreateAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlert", reflect.TypeOf((*MockAlertStorage)(nil).CreateAlert), ctx, alert)
+}
+
+// DeleteAlert mocks base method.
+func (m *MockAlertStorage) DeleteAlert(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlert", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlert indicates an expected call of DeleteAlert.
+func (mr *MockAlertStorageMockRecorder) DeleteAlert(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlert", reflect.TypeOf((*MockAlertStorage)(nil).DeleteAlert), ctx, id)
+}
+
+// GetAlert mocks base method.
+func (m *MockAlertStorage) GetAlert(ctx context.Context, id int64) (*entities.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlert", ctx, id)
+	ret0, _ := ret[0].(*entities.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlert indicates an expected call of GetAlert.
+func (mr *MockAlertStorageMockRecorder) GetAlert(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlert", reflect.TypeOf((*MockAlertStorage)(nil).GetAlert), ctx, id)
+}
+
+// GetAlerts mocks base method.
+func (m *MockAlertStorage) GetAlerts(ctx context.Context) ([]entities.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", ctx)
+	ret0, _ := ret[0].([]entities.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockAlertStorageMockRecorder) GetAlerts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockAlertStorage)(nil).GetAlerts), ctx)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockAlertStorage) GetDeliveries(ctx context.Context, alertID int64, limit int) ([]entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, alertID, limit)
+	ret0, _ := ret[0].([]entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockAlertStorageMockRecorder) GetDeliveries(ctx, alertID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockAlertStorage)(nil).GetDeliveries), ctx, alertID, limit)
+}
+
+// SetAlertState mocks base method.
+func (m *MockAlertStorage) SetAlertState(ctx context.Context, id int64, lastPrice decimal.Decimal, lastFiredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlertState", ctx, id, lastPrice, lastFiredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlertState indicates an expected call of SetAlertState.
+func (mr *MockAlertStorageMockRecorder) SetAlertState(ctx, id, lastPrice, lastFiredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlertState", reflect.TypeOf((*MockAlertStorage)(nil).SetAlertState), ctx, id, lastPrice, lastFiredAt)
+}
+
+// StoreDelivery mocks base method.
+func (m *MockAlertStorage) StoreDelivery(ctx context.Context, delivery *entities.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreDelivery indicates an expected call of StoreDelivery.
+func (mr *MockAlertStorageMockRecorder) StoreDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreDelivery", reflect.TypeOf((*MockAlertStorage)(nil).StoreDelivery), ctx, delivery)
+}
+
+// UpdateAlert mocks base method.
+func (m *MockAlertStorage) UpdateAlert(ctx context.Context, alert *entities.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlert", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlert indicates an expected call of UpdateAlert.
+func (mr *MockAlertStorageMockRecorder) UpdateAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlert", reflect.TypeOf((*MockAlertStorage)(nil).UpdateAlert), ctx, alert)
+}
@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"context"
+	"currency/internal/entities"
+)
+
+//go:generate mockgen -source=notifier.go -destination=./mocks/notifier_mock.go -package=mock
+type Notifier interface {
+	// Notify makes a single delivery attempt of alert fired by coin and
+	// returns the response status code, zero if there was no response.
+	Notify(ctx context.Context, alert entities.Alert, coin entities.Coin) (int, error)
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+type AggFunc int
+
+const (
+	_ AggFunc = iota
+	Max
+	Min
+	Avg
+)
+
+type Options struct {
+	FuncType AggFunc
+	Quotes   []string
+	From     time.Time
+	To       time.Time
+	Window   time.Duration
+}
+
+type Option func(opts *Options)
+
+func (af AggFunc) String() string {
+	return [...]string{"", "MAX", "MIN", "AVG"}[af]
+}
+
+func WithMaxFunc() Option {
+	return func(opts *Options) {
+		opts.FuncType = Max
+	}
+}
+
+func WithMinFunc() Option {
+	return func(opts *Options) {
+		opts.FuncType = Min
+	}
+}
+
+func WithAvgFunc() Option {
+	return func(opts *Options) {
+		opts.FuncType = Avg
+	}
+}
+
+// WithQuotes restricts the result to the given quote currencies.
+// Without it prices for every stored quote are returned.
+func WithQuotes(quotes ...string) Option {
+	return func(opts *Options) {
+		opts.Quotes = quotes
+	}
+}
+
+// WithRange limits the query to prices created in [from, to].
+// A zero bound leaves that side of the range open.
+func WithRange(from, to time.Time) Option {
+	return func(opts *Options) {
+		opts.From = from
+		opts.To = to
+	}
+}
+
+// WithWindow limits the query to prices created during the last d.
+// It is resolved against the current time when the query runs.
+func WithWindow(d time.Duration) Option {
+	return func(opts *Options) {
+		opts.Window = d
+	}
+}
+
+// Range returns the effective bounds of the query relative to now.
+// Zero values mean the bound is open.
+func (o *Options) Range(now time.Time) (from, to time.Time) {
+	if o.Window > 0 {
+		return now.Add(-o.Window), time.Time{}
+	}
+	return o.From, o.To
+}
+
+func validateOptions(opts []Option) error {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Window < 0 {
+		return errors.Wrap(entities.ErrInvalidParams, "window is negative")
+	}
+	if o.Window > 0 && (!o.From.IsZero() || !o.To.IsZero()) {
+		return errors.Wrap(entities.ErrInvalidParams, "window can't be combined with from/to")
+	}
+	if !o.From.IsZero() && !o.To.IsZero() && o.From.After(o.To) {
+		return errors.Wrap(entities.ErrInvalidParams, "from is after to")
+	}
+	return nil
+}
+
+// ParseWindow parses a relative window such as "90m", "24h" or "7d".
+// Besides the units accepted by time.ParseDuration it understands days (d)
+// and weeks (w).
+func ParseWindow(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v <= 0 {
+				return 0, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("window: %s", s))
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("window: %s", s))
+	}
+	return d, nil
+}
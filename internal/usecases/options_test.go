@@ -0,0 +1,102 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "hours", window: "24h", want: 24 * time.Hour},
+		{name: "minutes", window: "90m", want: 90 * time.Minute},
+		{name: "days", window: "7d", want: 7 * 24 * time.Hour},
+		{name: "weeks", window: "2w", want: 14 * 24 * time.Hour},
+		{name: "zero", window: "0h", wantErr: true},
+		{name: "negative days", window: "-1d", wantErr: true},
+		{name: "garbage", window: "week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := usecases.ParseWindow(tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWindow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !errors.Is(err, entities.ErrInvalidParams) {
+				t.Errorf("ParseWindow() error = %v, want ErrInvalidParams", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptions_Range(t *testing.T) {
+	now := time.Date(2025, 5, 17, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-time.Hour)
+
+	opts := &usecases.Options{}
+	usecases.WithWindow(24 * time.Hour)(opts)
+	gotFrom, gotTo := opts.Range(now)
+	if !gotFrom.Equal(now.Add(-24*time.Hour)) || !gotTo.IsZero() {
+		t.Errorf("Range() = %v, %v, want %v, zero", gotFrom, gotTo, now.Add(-24*time.Hour))
+	}
+
+	opts = &usecases.Options{}
+	usecases.WithRange(from, now)(opts)
+	gotFrom, gotTo = opts.Range(now)
+	if !gotFrom.Equal(from) || !gotTo.Equal(now) {
+		t.Errorf("Range() = %v, %v, want %v, %v", gotFrom, gotTo, from, now)
+	}
+}
+
+func TestService_GetMaxPrice_InvalidRange(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		opts []usecases.Option
+	}{
+		{
+			name: "from after to",
+			opts: []usecases.Option{usecases.WithRange(now, now.Add(-time.Hour))},
+		},
+		{
+			name: "window with range",
+			opts: []usecases.Option{usecases.WithWindow(time.Hour), usecases.WithRange(now.Add(-time.Hour), time.Time{})},
+		},
+		{
+			name: "negative window",
+			opts: []usecases.Option{usecases.WithWindow(-time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, _ := usecases.NewService(mock.NewMockStorage(ctrl), mock.NewMockClient(ctrl), logging.Nop())
+
+			_, err := s.GetMaxPrice(context.Background(), []string{"BTC"}, nil, tt.opts...)
+			if !errors.Is(err, entities.ErrInvalidParams) {
+				t.Errorf("GetMaxPrice() error = %v, want ErrInvalidParams", err)
+			}
+		})
+	}
+}
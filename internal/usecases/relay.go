@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+const DefaultRelayInterval = time.Second
+
+// Relay publishes the coins stored by any instance to the subscribers of this
+// one. With leader election only the leader fetches prices, so without it the
+// streams of the followers stay silent. Once a relay is created
+// GetCoinsFromAPI leaves publishing to it, so every coin is published once.
+type Relay struct {
+	service  *Service
+	interval time.Duration
+	// lastID is the id of the last published coin; started tells whether the
+	// first Poll has read it.
+	lastID  int64
+	started bool
+
+	stopping chan struct{}
+	done     chan struct{}
+}
+
+// NewRelay polls the storage of service every interval, DefaultRelayInterval
+// when zero.
+func NewRelay(service *Service, interval time.Duration) (*Relay, error) {
+	if service == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "service is nil")
+	}
+	if interval < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, fmt.Sprintf("interval: %s", interval))
+	}
+	if interval == 0 {
+		interval = DefaultRelayInterval
+	}
+	service.relayed.Store(true)
+
+	return &Relay{
+		service:  service,
+		interval: interval,
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Run polls every interval until Stop.
+func (r *Relay) Run() error {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.Poll(context.Background())
+
+		select {
+		case <-ticker.C:
+		case <-r.stopping:
+			return nil
+		}
+	}
+}
+
+// Poll publishes the coins stored since the previous Poll. The first one only
+// remembers the latest id: subscribers get the prices stored from then on.
+func (r *Relay) Poll(ctx context.Context) {
+	logger := r.service.logger
+	if !r.started {
+		id, err := r.service.storage.LastID(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx, "get last coin id failed", slog.Any("error", err))
+			return
+		}
+		r.lastID, r.started = id, true
+		return
+	}
+
+	for {
+		coins, err := r.service.storage.GetAfter(ctx, r.lastID, nil, MaxReplay)
+		if err != nil {
+			logger.ErrorContext(ctx, "get stored coins failed", slog.Any("error", err))
+			return
+		}
+		if len(coins) == 0 {
+			return
+		}
+		r.service.broker.publish(coins)
+		r.lastID = coins[len(coins)-1].ID
+		if len(coins) < MaxReplay {
+			return
+		}
+	}
+}
+
+// Stop waits for the poll in progress, if any.
+func (r *Relay) Stop(ctx context.Context) error {
+	close(r.stopping)
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(entities.ErrInternalServer, "relay is still polling")
+	}
+}
@@ -0,0 +1,94 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelay(t *testing.T) {
+	_, err := usecases.NewRelay(nil, 0)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+
+	s := newStreamService(t)
+	_, err = usecases.NewRelay(s, -1)
+	assert.ErrorIs(t, err, entities.ErrInvalidParams)
+}
+
+func TestRelay_Poll(t *testing.T) {
+	t.Run("publishes the coins stored after the first poll", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stored := []entities.Coin{
+			{ID: 6, Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1)},
+			{ID: 7, Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(2)},
+		}
+		storage := mock.NewMockStorage(ctrl)
+		gomock.InOrder(
+			storage.EXPECT().LastID(gomock.Any()).Return(int64(5), nil),
+			storage.EXPECT().GetAfter(gomock.Any(), int64(5), nil, usecases.MaxReplay).Return(stored, nil),
+			storage.EXPECT().GetAfter(gomock.Any(), int64(7), nil, usecases.MaxReplay).Return(nil, nil),
+		)
+		s, err := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+		require.NoError(t, err)
+		relay, err := usecases.NewRelay(s, 0)
+		require.NoError(t, err)
+
+		sub := s.Subscribe([]string{"BTC"}, nil)
+		defer sub.Close()
+
+		ctx := context.Background()
+		relay.Poll(ctx)
+		relay.Poll(ctx)
+		<-sub.Ready()
+		assert.Equal(t, stored[:1], sub.Next())
+
+		relay.Poll(ctx)
+		assert.Empty(t, sub.Next())
+	})
+
+	t.Run("retries the last id after an error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storage := mock.NewMockStorage(ctrl)
+		gomock.InOrder(
+			storage.EXPECT().LastID(gomock.Any()).Return(int64(0), entities.ErrInternalServer),
+			storage.EXPECT().LastID(gomock.Any()).Return(int64(0), nil),
+			storage.EXPECT().GetAfter(gomock.Any(), int64(0), nil, usecases.MaxReplay).Return(nil, nil),
+		)
+		s, err := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+		require.NoError(t, err)
+		relay, err := usecases.NewRelay(s, 0)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		for i := 0; i < 3; i++ {
+			relay.Poll(ctx)
+		}
+	})
+
+	t.Run("GetCoinsFromAPI leaves publishing to the relay", func(t *testing.T) {
+		coins := []entities.Coin{{ID: 1, Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1)}}
+		s := newStreamService(t, coins)
+		_, err := usecases.NewRelay(s, 0)
+		require.NoError(t, err)
+
+		sub := s.Subscribe(nil, nil)
+		defer sub.Close()
+
+		_, err = s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, sub.Next())
+	})
+}
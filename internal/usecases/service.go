@@ -2,9 +2,13 @@ package usecases
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"currency/internal/entities"
+	"currency/internal/tracing"
 
 	"github.com/pkg/errors"
 )
@@ -12,126 +16,148 @@ import (
 type Service struct {
 	storage Storage
 	client  Client
+	broker  *broker
+	alerts  *alerting
+	logger  *slog.Logger
+	health  HealthConfig
+	// leader is nil when every instance ingests prices.
+	leader Leader
+	// lastFetch is the UnixNano time of the last successful GetCoinsFromAPI.
+	lastFetch atomic.Int64
+	// relayed is set by NewRelay, which then publishes the stored coins.
+	relayed atomic.Bool
 }
 
-func NewService(storage Storage, client Client) (*Service, error) {
+func NewService(storage Storage, client Client, logger *slog.Logger) (*Service, error) {
 	if storage == nil {
 		return nil, errors.Wrap(entities.ErrInvalidParams, "storage is nil")
 	}
 	if client == nil {
 		return nil, errors.Wrap(entities.ErrInvalidParams, "client is nil")
 	}
-	return &Service{storage: storage, client: client}, nil
+	if logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "logger is nil")
+	}
+	return &Service{storage: storage, client: client, broker: newBroker(), logger: logger, health: DefaultHealthConfig}, nil
 }
 
-type AggFunc int
-
-const (
-	_ AggFunc = iota
-	Max
-	Min
-	Avg
-)
-
-type Options struct {
-	FuncType AggFunc
+func (s *Service) GetLastPrice(ctx context.Context, titles, quotes []string, opts ...Option) ([]entities.Coin, error) {
+	return s.get(ctx, "GetLastPrice", titles, quotes, nil, opts)
 }
 
-type Option func(opts *Options)
-
-func (af AggFunc) String() string {
-	return [...]string{"", "MAX", "MIN", "AVG"}[af]
+func (s *Service) GetMaxPrice(ctx context.Context, titles, quotes []string, opts ...Option) ([]entities.Coin, error) {
+	return s.get(ctx, "GetMaxPrice", titles, quotes, WithMaxFunc(), opts)
 }
 
-func WithMaxFunc() Option {
-	return func(opts *Options) {
-		opts.FuncType = Max
-	}
+func (s *Service) GetMinPrice(ctx context.Context, titles, quotes []string, opts ...Option) ([]entities.Coin, error) {
+	return s.get(ctx, "GetMinPrice", titles, quotes, WithMinFunc(), opts)
 }
 
-func WithMinFunc() Option {
-	return func(opts *Options) {
-		opts.FuncType = Min
-	}
+func (s *Service) GetAvgPrice(ctx context.Context, titles, quotes []string, opts ...Option) ([]entities.Coin, error) {
+	return s.get(ctx, "GetAvgPrice", titles, quotes, WithAvgFunc(), opts)
 }
 
-func WithAvgFunc() Option {
-	return func(opts *Options) {
-		opts.FuncType = Avg
-	}
-}
+// get reads the prices of titles aggregated by fn, the last ones if fn is
+// nil. name is the span and the error of the calling method.
+func (s *Service) get(ctx context.Context, name string, titles, quotes []string, fn Option, opts []Option) (_ []entities.Coin, err error) {
+	ctx, span := tracer.Start(ctx, "Service."+name, tracing.Symbols(titles, quotes))
+	defer tracing.End(span, &err)
 
-func (s *Service) GetLastPrice(ctx context.Context, titles []string) ([]entities.Coin, error) {
-	coins, err := s.storage.Get(ctx, titles)
-	if errors.Is(err, entities.ErrInvalidParams) {
-		return nil, errors.Wrap(entities.ErrInvalidParams, "incorrect parameters")
-	}
-	if err != nil {
-		return nil, errors.Wrap(entities.ErrGetFunc, "GetLastPrice")
+	if err := validateOptions(opts); err != nil {
+		return nil, err
 	}
 
-	return coins, nil
-}
-
-func (s *Service) GetMaxPrice(ctx context.Context, titles []string) ([]entities.Coin, error) {
-	coins, err := s.storage.Get(ctx, titles, WithMaxFunc())
-	if errors.Is(err, entities.ErrInvalidParams) {
-		return nil, errors.Wrap(entities.ErrInvalidParams, "incorrect parameters")
-	}
-
-	if err != nil {
-		return nil, errors.Wrap(entities.ErrGetFunc, "GetMaxPrice")
+	if fn != nil {
+		opts = append(opts, fn)
 	}
-
-	return coins, nil
-}
-
-func (s *Service) GetMinPrice(ctx context.Context, titles []string) ([]entities.Coin, error) {
-	coins, err := s.storage.Get(ctx, titles, WithMinFunc())
+	opts = append(opts, WithQuotes(quotes...))
+	coins, err := s.storage.Get(ctx, titles, opts...)
 	if errors.Is(err, entities.ErrInvalidParams) {
 		return nil, errors.Wrap(entities.ErrInvalidParams, "incorrect parameters")
 	}
-
-	if err != nil {
-		return nil, errors.Wrap(entities.ErrGetFunc, "GetMinPrice")
-	}
-
-	return coins, nil
-}
-
-func (s *Service) GetAvgPrice(ctx context.Context, titles []string) ([]entities.Coin, error) {
-	coins, err := s.storage.Get(ctx, titles, WithAvgFunc())
-	if errors.Is(err, entities.ErrInvalidParams) {
-		return nil, errors.Wrap(entities.ErrInvalidParams, "incorrect parameters")
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, errors.Wrap(entities.ErrNotFound, "prices are not stored")
 	}
-
 	if err != nil {
-
-		return nil, errors.Wrap(entities.ErrGetFunc, "GetAvgPrice")
+		return nil, errors.Wrap(entities.ErrGetFunc, name)
 	}
 
 	return coins, nil
 }
 
-func (s *Service) GetCoinsFromAPI(ctx context.Context, titles ...string) ([]entities.Coin, error) {
+// GetCoinsFromAPI fetches prices from the client, stores them and publishes
+// them to subscribers, unless a Relay does, and alerts. Empty titles fall back to the tracked symbols
+// that aren't paused, empty quotes to the client defaults.
+func (s *Service) GetCoinsFromAPI(ctx context.Context, titles, quotes []string) (_ []entities.Coin, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetCoinsFromAPI", tracing.Symbols(titles, quotes))
+	defer tracing.End(span, &err)
+
 	if len(titles) == 0 {
-		ts, err := s.storage.GetTitles(ctx)
+		ts, err := s.trackedTitles(ctx)
 		if err != nil {
 			return nil, errors.Wrap(entities.ErrGetFunc, "GetCoinsFromAPI")
 		}
+		if len(ts) == 0 {
+			return nil, nil
+		}
 		titles = ts
 	}
 
-	coins, err := s.client.GetCoins(ctx, titles)
+	coins, err := s.client.GetCoins(ctx, titles, quotes)
 	if err != nil {
 		return nil, errors.Wrap(entities.ErrGetFunc, "GetCoinsFromAPI")
 	}
 
 	err = s.storage.Store(ctx, coins)
 	if err != nil {
-		fmt.Println(err)
+		s.logger.ErrorContext(ctx, "store coins failed", slog.Any("error", err))
 		return nil, errors.Wrap(entities.ErrGetFunc, "GetCoinsFromAPI")
 	}
+	s.lastFetch.Store(time.Now().UnixNano())
+
+	if !s.relayed.Load() {
+		s.broker.publish(coins)
+	}
+	s.evaluateAlerts(ctx, coins)
 
 	return coins, nil
 }
+
+// FetchMissing calls GetCoinsFromAPI for the titles without any stored price
+// in quotes, so a read that misses some titles doesn't fetch the stored ones
+// again. Empty quotes match every quote.
+func (s *Service) FetchMissing(ctx context.Context, titles, quotes []string) (_ []entities.Coin, err error) {
+	ctx, span := tracer.Start(ctx, "Service.FetchMissing", tracing.Symbols(titles, quotes))
+	defer tracing.End(span, &err)
+
+	updates, err := s.storage.GetUpdates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "FetchMissing")
+	}
+	stored := make(map[string]bool)
+	for _, update := range updates {
+		if len(quotes) == 0 || slices.Contains(quotes, update.Quote) {
+			stored[update.Title] = true
+		}
+	}
+	var missing []string
+	for _, t := range titles {
+		if !stored[t] {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	return s.GetCoinsFromAPI(ctx, missing, quotes)
+}
+
+// Shutdown stops alert delivery retries and waits for the deliveries in
+// flight until ctx is done. Alerts are not evaluated after it.
+func (s *Service) Shutdown(ctx context.Context) error {
+	if s.alerts == nil {
+		return nil
+	}
+	return s.alerts.shutdown(ctx)
+}
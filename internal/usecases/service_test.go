@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"currency/internal/entities"
+	"currency/internal/logging"
 	"currency/internal/usecases"
 	mock "currency/internal/usecases/mocks"
 
@@ -21,6 +22,7 @@ func TestService_GetLastPrice(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		titles []string
+		quotes []string
 	}
 	tests := []struct {
 		name    string
@@ -36,7 +38,7 @@ func TestService_GetLastPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any()).Return(nil, entities.ErrInvalidParams)
 			},
 			wantErr: true,
 			want:    nil,
@@ -48,7 +50,7 @@ func TestService_GetLastPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any()).Return(nil, entities.ErrNotFound)
 			},
 			wantErr: true,
 			want:    nil,
@@ -60,7 +62,7 @@ func TestService_GetLastPrice(t *testing.T) {
 				titles: []string{"BTC, USDT"},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any()).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
 			},
 			wantErr: false,
 			want:    []entities.Coin{{Title: "BTC"}, {Title: "USDT"}},
@@ -79,9 +81,9 @@ func TestService_GetLastPrice(t *testing.T) {
 
 			tt.prepare(&f, tt.args)
 
-			s, _ := usecases.NewService(f.storage, f.client)
+			s, _ := usecases.NewService(f.storage, f.client, logging.Nop())
 
-			got, err := s.GetLastPrice(tt.args.ctx, tt.args.titles)
+			got, err := s.GetLastPrice(tt.args.ctx, tt.args.titles, tt.args.quotes)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetLastPrice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -101,7 +103,7 @@ func TestService_GetMaxPrice(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		titles []string
-		option usecases.Option
+		quotes []string
 	}
 	tests := []struct {
 		name    string
@@ -117,7 +119,7 @@ func TestService_GetMaxPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return(nil, entities.ErrInvalidParams)
 			},
 			wantErr: true,
 			want:    nil,
@@ -129,7 +131,7 @@ func TestService_GetMaxPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return(nil, entities.ErrNotFound)
 			},
 			wantErr: true,
 			want:    nil,
@@ -141,7 +143,7 @@ func TestService_GetMaxPrice(t *testing.T) {
 				titles: []string{"BTC, USDT"},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
 			},
 			wantErr: false,
 			want:    []entities.Coin{{Title: "BTC"}, {Title: "USDT"}},
@@ -160,9 +162,9 @@ func TestService_GetMaxPrice(t *testing.T) {
 
 			tt.prepare(&f, tt.args)
 
-			s, _ := usecases.NewService(f.storage, f.client)
+			s, _ := usecases.NewService(f.storage, f.client, logging.Nop())
 
-			got, err := s.GetMaxPrice(tt.args.ctx, tt.args.titles)
+			got, err := s.GetMaxPrice(tt.args.ctx, tt.args.titles, tt.args.quotes)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetMaxPrice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -182,6 +184,7 @@ func TestService_GetMinPrice(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		titles []string
+		quotes []string
 	}
 	tests := []struct {
 		name    string
@@ -197,7 +200,7 @@ func TestService_GetMinPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return(nil, entities.ErrInvalidParams)
 			},
 			wantErr: true,
 			want:    nil,
@@ -209,7 +212,7 @@ func TestService_GetMinPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return(nil, entities.ErrNotFound)
 			},
 			wantErr: true,
 			want:    nil,
@@ -221,7 +224,7 @@ func TestService_GetMinPrice(t *testing.T) {
 				titles: []string{"BTC, USDT"},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
 			},
 			wantErr: false,
 			want:    []entities.Coin{{Title: "BTC"}, {Title: "USDT"}},
@@ -240,9 +243,9 @@ func TestService_GetMinPrice(t *testing.T) {
 
 			tt.prepare(&f, tt.args)
 
-			s, _ := usecases.NewService(f.storage, f.client)
+			s, _ := usecases.NewService(f.storage, f.client, logging.Nop())
 
-			got, err := s.GetMinPrice(tt.args.ctx, tt.args.titles)
+			got, err := s.GetMinPrice(tt.args.ctx, tt.args.titles, tt.args.quotes)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetMinPrice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -262,6 +265,7 @@ func TestService_GetAvgPrice(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		titles []string
+		quotes []string
 	}
 	tests := []struct {
 		name    string
@@ -277,7 +281,7 @@ func TestService_GetAvgPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return(nil, entities.ErrInvalidParams)
 			},
 			wantErr: true,
 			want:    nil,
@@ -289,7 +293,7 @@ func TestService_GetAvgPrice(t *testing.T) {
 				titles: []string{},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return(nil, entities.ErrInvalidParams)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return(nil, entities.ErrNotFound)
 			},
 			wantErr: true,
 			want:    nil,
@@ -301,7 +305,7 @@ func TestService_GetAvgPrice(t *testing.T) {
 				titles: []string{"BTC, USDT"},
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().Get(args.ctx, args.titles, gomock.Any()).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
+				f.storage.EXPECT().Get(gomock.Any(), args.titles, gomock.Any(), gomock.Any()).Return([]entities.Coin{{Title: "BTC"}, {Title: "USDT"}}, nil)
 			},
 			wantErr: false,
 			want:    []entities.Coin{{Title: "BTC"}, {Title: "USDT"}},
@@ -320,9 +324,9 @@ func TestService_GetAvgPrice(t *testing.T) {
 
 			tt.prepare(&f, tt.args)
 
-			s, _ := usecases.NewService(f.storage, f.client)
+			s, _ := usecases.NewService(f.storage, f.client, logging.Nop())
 
-			got, err := s.GetAvgPrice(tt.args.ctx, tt.args.titles)
+			got, err := s.GetAvgPrice(tt.args.ctx, tt.args.titles, tt.args.quotes)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetAvgPrice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -342,6 +346,7 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		titles []string
+		quotes []string
 	}
 	tests := []struct {
 		name    string
@@ -351,13 +356,13 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 		want    []entities.Coin
 	}{
 		{
-			name: "GetCoinsFromAPI() failed - titles is nil and s.storage.GetTitles() failed",
+			name: "GetCoinsFromAPI() failed - titles is nil and s.storage.GetSymbols() failed",
 			args: args{
 				ctx:    context.Background(),
 				titles: nil,
 			},
 			prepare: func(f *fields, args args) {
-				f.storage.EXPECT().GetTitles(args.ctx).Return(nil, errors.New("s.storage.GetTitles() failed"))
+				f.storage.EXPECT().GetSymbols(gomock.Any()).Return(nil, errors.New("s.storage.GetSymbols() failed"))
 			},
 			wantErr: true,
 			want:    nil,
@@ -370,9 +375,10 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 			},
 			prepare: func(f *fields, args args) {
 				titles := []string{"BTC", "ETH"}
+				symbols := []entities.Symbol{{Title: "BTC"}, {Title: "DOGE", Paused: true}, {Title: "ETH"}}
 				gomock.InOrder(
-					f.storage.EXPECT().GetTitles(args.ctx).Return(titles, nil),
-					f.client.EXPECT().GetCoins(args.ctx, titles).Return(nil, errors.New("s.client.GetCoins() failed")),
+					f.storage.EXPECT().GetSymbols(gomock.Any()).Return(symbols, nil),
+					f.client.EXPECT().GetCoins(gomock.Any(), titles, args.quotes).Return(nil, errors.New("s.client.GetCoins() failed")),
 				)
 			},
 			wantErr: true,
@@ -386,11 +392,12 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 			},
 			prepare: func(f *fields, args args) {
 				titles := []string{"BTC", "ETH"}
+				symbols := []entities.Symbol{{Title: "BTC"}, {Title: "DOGE", Paused: true}, {Title: "ETH"}}
 				coins := []entities.Coin{{Title: "BTC"}, {Title: "ETH"}}
 				gomock.InOrder(
-					f.storage.EXPECT().GetTitles(args.ctx).Return(titles, nil),
-					f.client.EXPECT().GetCoins(args.ctx, titles).Return(coins, nil),
-					f.storage.EXPECT().Store(args.ctx, coins).Return(errors.New("s.store failed")),
+					f.storage.EXPECT().GetSymbols(gomock.Any()).Return(symbols, nil),
+					f.client.EXPECT().GetCoins(gomock.Any(), titles, args.quotes).Return(coins, nil),
+					f.storage.EXPECT().Store(gomock.Any(), coins).Return(errors.New("s.store failed")),
 				)
 			},
 			wantErr: true,
@@ -404,16 +411,29 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 			},
 			prepare: func(f *fields, args args) {
 				titles := []string{"BTC", "ETH"}
+				symbols := []entities.Symbol{{Title: "BTC"}, {Title: "DOGE", Paused: true}, {Title: "ETH"}}
 				coins := []entities.Coin{{Title: "BTC"}, {Title: "ETH"}}
 				gomock.InOrder(
-					f.storage.EXPECT().GetTitles(args.ctx).Return(titles, nil),
-					f.client.EXPECT().GetCoins(args.ctx, titles).Return(coins, nil),
-					f.storage.EXPECT().Store(args.ctx, coins).Return(nil),
+					f.storage.EXPECT().GetSymbols(gomock.Any()).Return(symbols, nil),
+					f.client.EXPECT().GetCoins(gomock.Any(), titles, args.quotes).Return(coins, nil),
+					f.storage.EXPECT().Store(gomock.Any(), coins).Return(nil),
 				)
 			},
 			wantErr: false,
 			want:    []entities.Coin{{Title: "BTC"}, {Title: "ETH"}},
 		},
+		{
+			name: "GetCoinsFromAPI() skipped - every symbol is paused",
+			args: args{
+				ctx:    context.Background(),
+				titles: nil,
+			},
+			prepare: func(f *fields, args args) {
+				f.storage.EXPECT().GetSymbols(gomock.Any()).Return([]entities.Symbol{{Title: "BTC", Paused: true}}, nil)
+			},
+			wantErr: false,
+			want:    nil,
+		},
 		{
 			name: "GetCoinsFromAPI() success with titles parameter",
 			args: args{
@@ -423,8 +443,8 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 			prepare: func(f *fields, args args) {
 				coins := []entities.Coin{{Title: "BTC"}, {Title: "ETH"}}
 				gomock.InOrder(
-					f.client.EXPECT().GetCoins(args.ctx, args.titles).Return(coins, nil),
-					f.storage.EXPECT().Store(args.ctx, coins).Return(nil),
+					f.client.EXPECT().GetCoins(gomock.Any(), args.titles, args.quotes).Return(coins, nil),
+					f.storage.EXPECT().Store(gomock.Any(), coins).Return(nil),
 				)
 			},
 			wantErr: false,
@@ -444,9 +464,9 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 
 			tt.prepare(&f, tt.args)
 
-			s, _ := usecases.NewService(f.storage, f.client)
+			s, _ := usecases.NewService(f.storage, f.client, logging.Nop())
 
-			got, err := s.GetCoinsFromAPI(tt.args.ctx, tt.args.titles...)
+			got, err := s.GetCoinsFromAPI(tt.args.ctx, tt.args.titles, tt.args.quotes)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetCoinsFromAPI() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -457,3 +477,66 @@ func TestService_GetCoinsFromAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestService_FetchMissing(t *testing.T) {
+	updates := []entities.SymbolStatus{{Title: "BTC", Quote: "EUR"}, {Title: "BTC", Quote: "USD"}, {Title: "ETH", Quote: "EUR"}}
+	tests := []struct {
+		name    string
+		quotes  []string
+		prepare func(storage *mock.MockStorage, client *mock.MockClient)
+		wantErr bool
+	}{
+		{
+			name:   "fetches the titles without prices in the quotes",
+			quotes: []string{"USD"},
+			prepare: func(storage *mock.MockStorage, client *mock.MockClient) {
+				coins := []entities.Coin{{Title: "ETH", Quote: "USD"}, {Title: "XRP", Quote: "USD"}}
+				gomock.InOrder(
+					storage.EXPECT().GetUpdates(gomock.Any()).Return(updates, nil),
+					client.EXPECT().GetCoins(gomock.Any(), []string{"ETH", "XRP"}, []string{"USD"}).Return(coins, nil),
+					storage.EXPECT().Store(gomock.Any(), coins).Return(nil),
+				)
+			},
+		},
+		{
+			name: "every quote",
+			prepare: func(storage *mock.MockStorage, client *mock.MockClient) {
+				gomock.InOrder(
+					storage.EXPECT().GetUpdates(gomock.Any()).Return(updates, nil),
+					client.EXPECT().GetCoins(gomock.Any(), []string{"XRP"}, nil).Return(nil, nil),
+					storage.EXPECT().Store(gomock.Any(), nil).Return(nil),
+				)
+			},
+		},
+		{
+			name:   "nothing is missing",
+			quotes: []string{"EUR"},
+			prepare: func(storage *mock.MockStorage, client *mock.MockClient) {
+				storage.EXPECT().GetUpdates(gomock.Any()).Return(append(updates, entities.SymbolStatus{Title: "XRP", Quote: "EUR"}), nil)
+			},
+		},
+		{
+			name: "storage error",
+			prepare: func(storage *mock.MockStorage, client *mock.MockClient) {
+				storage.EXPECT().GetUpdates(gomock.Any()).Return(nil, errors.New("s.storage.GetUpdates() failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage, client := mock.NewMockStorage(ctrl), mock.NewMockClient(ctrl)
+			tt.prepare(storage, client)
+			s, _ := usecases.NewService(storage, client, logging.Nop())
+
+			_, err := s.FetchMissing(context.Background(), []string{"BTC", "ETH", "XRP"}, tt.quotes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FetchMissing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
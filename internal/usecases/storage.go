@@ -3,11 +3,45 @@ package usecases
 import (
 	"context"
 	"currency/internal/entities"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 //go:generate mockgen -source=storage.go -destination=./mocks/storage_mock.go -package=mock
 type Storage interface {
+	// Store sets the ID of coins. Concurrent Stores commit in the order of
+	// the ids, so GetAfter never skips a coin that becomes visible late.
 	Store(ctx context.Context, coins []entities.Coin) error
+	// Get reports a title without any stored price in the quotes as
+	// entities.ErrNotFound and leaves out a title without prices in the range.
 	Get(ctx context.Context, titles []string, opt ...Option) ([]entities.Coin, error)
-	GetTitles(ctx context.Context) ([]string, error)
+	GetCandles(ctx context.Context, titles []string, interval time.Duration, opt ...Option) ([]entities.Candle, error)
+	GetAfter(ctx context.Context, id int64, titles []string, limit int, opt ...Option) ([]entities.Coin, error)
+	// LastID returns the id of the latest stored coin, 0 if none is.
+	LastID(ctx context.Context) (int64, error)
+	// AddSymbol stores symbol and sets its CreateTime; a tracked title is
+	// reported as entities.ErrConflict, an untracked one by SetSymbolPaused
+	// and DeleteSymbol as entities.ErrNotFound.
+	AddSymbol(ctx context.Context, symbol *entities.Symbol) error
+	GetSymbols(ctx context.Context) ([]entities.Symbol, error)
+	SetSymbolPaused(ctx context.Context, title string, paused bool) (*entities.Symbol, error)
+	DeleteSymbol(ctx context.Context, title string) error
+	// GetUpdates returns the time of the latest stored price of every pair,
+	// ordered by title and quote.
+	GetUpdates(ctx context.Context) ([]entities.SymbolStatus, error)
+	Ping(ctx context.Context) error
+}
+
+// AlertStorage persists alert rules and their delivery log. Missing alerts
+// are reported as entities.ErrNotFound.
+type AlertStorage interface {
+	CreateAlert(ctx context.Context, alert *entities.Alert) error
+	GetAlert(ctx context.Context, id int64) (*entities.Alert, error)
+	GetAlerts(ctx context.Context) ([]entities.Alert, error)
+	UpdateAlert(ctx context.Context, alert *entities.Alert) error
+	DeleteAlert(ctx context.Context, id int64) error
+	SetAlertState(ctx context.Context, id int64, lastPrice decimal.Decimal, lastFiredAt time.Time) error
+	StoreDelivery(ctx context.Context, delivery *entities.Delivery) error
+	GetDeliveries(ctx context.Context, alertID int64, limit int) ([]entities.Delivery, error)
 }
@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"currency/internal/entities"
+	"currency/internal/tracing"
+
+	"github.com/pkg/errors"
+)
+
+type pair struct{ title, quote string }
+
+// Subscription receives the stored coins that match its titles and quotes.
+// A subscription created without titles matches every title but the
+// unsubscribed ones until Subscribe narrows it; an empty quote set matches
+// every quote. Updates are coalesced per title and quote: a consumer that
+// falls behind gets the latest price of every pair instead of a growing
+// backlog, so publishing never blocks.
+type Subscription struct {
+	mu     sync.Mutex
+	all    bool
+	titles map[string]bool
+	// excluded are the titles unsubscribed while all is set.
+	excluded map[string]bool
+	quotes   map[string]bool
+	pending  map[pair]entities.Coin
+	// since is the smallest id published to the subscription, 0 before any.
+	since  int64
+	ready  chan struct{}
+	closed bool
+	broker *broker
+}
+
+// Ready is signalled whenever Next has coins to return. It is closed when the
+// subscription is closed.
+func (s *Subscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Next returns the pending coins ordered by title and quote and clears them.
+func (s *Subscription) Next() []entities.Coin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	coins := make([]entities.Coin, 0, len(s.pending))
+	for _, coin := range s.pending {
+		coins = append(coins, coin)
+	}
+	s.pending = make(map[pair]entities.Coin)
+
+	sort.Slice(coins, func(i, j int) bool {
+		if coins[i].Title != coins[j].Title {
+			return coins[i].Title < coins[j].Title
+		}
+		return coins[i].Quote < coins[j].Quote
+	})
+	return coins
+}
+
+// Since returns the smallest id of the coins published to the subscription,
+// 0 if none was. A consumer that needs every stored coin rather than the
+// coalesced latest ones reads them with GetCoinsAfter(Since()-1) and on.
+func (s *Subscription) Since() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.since
+}
+
+// Subscribe adds titles and quotes to the filter.
+func (s *Subscription) Subscribe(titles, quotes []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(titles) > 0 {
+		s.all = false
+		s.excluded = make(map[string]bool)
+	}
+	for _, t := range titles {
+		s.titles[t] = true
+	}
+	for _, q := range quotes {
+		s.quotes[q] = true
+	}
+}
+
+// Unsubscribe removes titles from the filter and drops their pending coins.
+// A subscription matching every title stops matching them.
+func (s *Subscription) Unsubscribe(titles []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range titles {
+		delete(s.titles, t)
+		if s.all {
+			s.excluded[t] = true
+		}
+		for p := range s.pending {
+			if p.title == t {
+				delete(s.pending, p)
+			}
+		}
+	}
+}
+
+// Titles returns the subscribed titles.
+func (s *Subscription) Titles() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	titles := make([]string, 0, len(s.titles))
+	for t := range s.titles {
+		titles = append(titles, t)
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+func (s *Subscription) Close() {
+	s.broker.remove(s)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ready)
+	}
+}
+
+func (s *Subscription) publish(coins []entities.Coin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	matched := false
+	for _, coin := range coins {
+		if s.all && s.excluded[coin.Title] || !s.all && !s.titles[coin.Title] {
+			continue
+		}
+		if len(s.quotes) > 0 && !s.quotes[coin.Quote] {
+			continue
+		}
+		s.pending[pair{coin.Title, coin.Quote}] = coin
+		if coin.ID != 0 && (s.since == 0 || coin.ID < s.since) {
+			s.since = coin.ID
+		}
+		matched = true
+	}
+	if matched {
+		select {
+		case s.ready <- struct{}{}:
+		default:
+		}
+	}
+}
+
+type broker struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{subs: make(map[*Subscription]struct{})}
+}
+
+func (b *broker) subscribe(titles, quotes []string) *Subscription {
+	s := &Subscription{
+		titles:   make(map[string]bool),
+		excluded: make(map[string]bool),
+		quotes:   make(map[string]bool),
+		pending:  make(map[pair]entities.Coin),
+		ready:    make(chan struct{}, 1),
+		broker:   b,
+		all:      len(titles) == 0,
+	}
+	s.Subscribe(titles, quotes)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[s] = struct{}{}
+	return s
+}
+
+func (b *broker) remove(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs, s)
+}
+
+func (b *broker) publish(coins []entities.Coin) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subs {
+		s.publish(coins)
+	}
+}
+
+// MaxReplay bounds the number of coins GetCoinsAfter returns at once.
+const MaxReplay = 1000
+
+// GetCoinsAfter returns up to MaxReplay coins stored after the coin with the
+// given ID, oldest first, so a stream consumer can catch up after
+// reconnecting. Empty titles and quotes match everything.
+func (s *Service) GetCoinsAfter(ctx context.Context, id int64, titles, quotes []string) (_ []entities.Coin, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetCoinsAfter", tracing.Symbols(titles, quotes))
+	defer tracing.End(span, &err)
+
+	if id < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParams, "id is negative")
+	}
+
+	coins, err := s.storage.GetAfter(ctx, id, titles, MaxReplay, WithQuotes(quotes...))
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrGetFunc, "GetCoinsAfter")
+	}
+
+	return coins, nil
+}
+
+// Subscribe streams the prices stored from now on for titles and quotes; empty
+// sets match everything. The caller must Close the subscription.
+func (s *Service) Subscribe(titles, quotes []string) *Subscription {
+	return s.broker.subscribe(titles, quotes)
+}
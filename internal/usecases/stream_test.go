@@ -0,0 +1,163 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamService(t *testing.T, batches ...[]entities.Coin) *usecases.Service {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	storage, client := mock.NewMockStorage(ctrl), mock.NewMockClient(ctrl)
+	for _, coins := range batches {
+		client.EXPECT().GetCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(coins, nil)
+		storage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+	}
+
+	s, err := usecases.NewService(storage, client, logging.Nop())
+	require.NoError(t, err)
+	return s
+}
+
+func TestService_Subscribe(t *testing.T) {
+	t.Run("filters by titles and quotes", func(t *testing.T) {
+		s := newStreamService(t, []entities.Coin{
+			{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1)},
+			{Title: "BTC", Quote: "RUB", Price: decimal.NewFromInt(2)},
+			{Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(3)},
+		})
+
+		sub := s.Subscribe([]string{"BTC"}, []string{"USD"})
+		defer sub.Close()
+
+		_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC", "ETH"}, nil)
+		require.NoError(t, err)
+
+		<-sub.Ready()
+		assert.Equal(t, []entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1)}}, sub.Next())
+	})
+
+	t.Run("slow consumer gets the latest price", func(t *testing.T) {
+		s := newStreamService(t,
+			[]entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1)}, {Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(10)}},
+			[]entities.Coin{{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(2)}},
+		)
+
+		sub := s.Subscribe(nil, nil)
+		defer sub.Close()
+
+		for i := 0; i < 2; i++ {
+			_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+			require.NoError(t, err)
+		}
+
+		<-sub.Ready()
+		assert.Equal(t, []entities.Coin{
+			{Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(2)},
+			{Title: "ETH", Quote: "USD", Price: decimal.NewFromInt(10)},
+		}, sub.Next())
+		assert.Empty(t, sub.Next())
+	})
+
+	t.Run("subscribe and unsubscribe", func(t *testing.T) {
+		s := newStreamService(t, []entities.Coin{{Title: "BTC", Quote: "USD"}, {Title: "ETH", Quote: "USD"}})
+
+		sub := s.Subscribe([]string{"BTC"}, nil)
+		defer sub.Close()
+
+		sub.Subscribe([]string{"ETH"}, nil)
+		sub.Unsubscribe([]string{"BTC"})
+		assert.Equal(t, []string{"ETH"}, sub.Titles())
+
+		_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+
+		<-sub.Ready()
+		assert.Equal(t, []entities.Coin{{Title: "ETH", Quote: "USD"}}, sub.Next())
+	})
+
+	t.Run("unsubscribe from every title", func(t *testing.T) {
+		s := newStreamService(t, []entities.Coin{{Title: "BTC", Quote: "USD"}, {Title: "ETH", Quote: "USD"}})
+
+		sub := s.Subscribe(nil, nil)
+		defer sub.Close()
+
+		sub.Unsubscribe([]string{"BTC"})
+		_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC", "ETH"}, nil)
+		require.NoError(t, err)
+
+		<-sub.Ready()
+		assert.Equal(t, []entities.Coin{{Title: "ETH", Quote: "USD"}}, sub.Next())
+	})
+
+	t.Run("closed subscription", func(t *testing.T) {
+		s := newStreamService(t, []entities.Coin{{Title: "BTC", Quote: "USD"}})
+
+		sub := s.Subscribe(nil, nil)
+		sub.Close()
+
+		_, err := s.GetCoinsFromAPI(context.Background(), []string{"BTC"}, nil)
+		require.NoError(t, err)
+
+		_, ok := <-sub.Ready()
+		assert.False(t, ok)
+		assert.Empty(t, sub.Next())
+	})
+}
+
+func TestService_GetCoinsAfter(t *testing.T) {
+	t.Run("negative id", func(t *testing.T) {
+		s := newStreamService(t)
+
+		_, err := s.GetCoinsAfter(context.Background(), -1, nil, nil)
+		assert.ErrorIs(t, err, entities.ErrInvalidParams)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storage := mock.NewMockStorage(ctrl)
+		storage.EXPECT().GetAfter(gomock.Any(), int64(5), []string{"BTC"}, usecases.MaxReplay, gomock.Any()).
+			Return(nil, entities.ErrInternalServer)
+		s, err := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+		require.NoError(t, err)
+
+		_, err = s.GetCoinsAfter(context.Background(), 5, []string{"BTC"}, nil)
+		assert.ErrorIs(t, err, entities.ErrGetFunc)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := []entities.Coin{{ID: 6, Title: "BTC", Quote: "USD", Price: decimal.NewFromInt(1)}}
+		storage := mock.NewMockStorage(ctrl)
+		storage.EXPECT().GetAfter(gomock.Any(), int64(5), []string{"BTC"}, usecases.MaxReplay, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, _ []string, _ int, opts ...usecases.Option) ([]entities.Coin, error) {
+				o := &usecases.Options{}
+				for _, opt := range opts {
+					opt(o)
+				}
+				assert.Equal(t, []string{"USD"}, o.Quotes)
+				return expected, nil
+			})
+		s, err := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+		require.NoError(t, err)
+
+		coins, err := s.GetCoinsAfter(context.Background(), 5, []string{"BTC"}, []string{"USD"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, coins)
+	})
+}
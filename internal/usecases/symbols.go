@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"currency/internal/entities"
+
+	"github.com/pkg/errors"
+)
+
+// AddSymbol starts tracking title. Its prices are fetched by the next
+// scheduled update.
+func (s *Service) AddSymbol(ctx context.Context, title string) (*entities.Symbol, error) {
+	symbol, err := entities.NewSymbol(title)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.AddSymbol(ctx, symbol); err != nil {
+		return nil, err
+	}
+	return symbol, nil
+}
+
+func (s *Service) GetSymbols(ctx context.Context) ([]entities.Symbol, error) {
+	return s.storage.GetSymbols(ctx)
+}
+
+// SetSymbolPaused pauses or resumes fetching the prices of title.
+func (s *Service) SetSymbolPaused(ctx context.Context, title string, paused bool) (*entities.Symbol, error) {
+	symbol, err := entities.NewSymbol(title)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.SetSymbolPaused(ctx, symbol.Title, paused)
+}
+
+// DeleteSymbol stops tracking title. Its stored prices are kept.
+func (s *Service) DeleteSymbol(ctx context.Context, title string) error {
+	symbol, err := entities.NewSymbol(title)
+	if err != nil {
+		return err
+	}
+	return s.storage.DeleteSymbol(ctx, symbol.Title)
+}
+
+// SeedSymbols tracks titles if no symbol is tracked yet, so the symbols
+// removed at runtime stay removed after a restart.
+func (s *Service) SeedSymbols(ctx context.Context, titles []string) error {
+	symbols, err := s.storage.GetSymbols(ctx)
+	if err != nil {
+		return errors.Wrap(err, "SeedSymbols")
+	}
+	if len(symbols) > 0 {
+		return nil
+	}
+
+	for _, title := range titles {
+		_, err := s.AddSymbol(ctx, title)
+		if err != nil && !errors.Is(err, entities.ErrConflict) {
+			return errors.Wrap(err, fmt.Sprintf("SeedSymbols: %s", title))
+		}
+	}
+	return nil
+}
+
+// trackedTitles returns the titles of the symbols that aren't paused.
+func (s *Service) trackedTitles(ctx context.Context) ([]string, error) {
+	symbols, err := s.storage.GetSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+	titles := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		if !symbol.Paused {
+			titles = append(titles, symbol.Title)
+		}
+	}
+	return titles, nil
+}
@@ -0,0 +1,113 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"currency/internal/entities"
+	"currency/internal/logging"
+	"currency/internal/usecases"
+	mock "currency/internal/usecases/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSymbolService(t *testing.T) (*usecases.Service, *mock.MockStorage) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	storage := mock.NewMockStorage(ctrl)
+	s, err := usecases.NewService(storage, mock.NewMockClient(ctrl), logging.Nop())
+	require.NoError(t, err)
+	return s, storage
+}
+
+func TestService_AddSymbol(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		storeErr error
+		want     string
+		wantErr  error
+	}{
+		{name: "normalizes title", title: " btc ", want: "BTC"},
+		{name: "invalid title", title: "BTC/USD", wantErr: entities.ErrInvalidParams},
+		{name: "empty title", title: "", wantErr: entities.ErrInvalidParams},
+		{
+			name:     "already tracked",
+			title:    "ETH",
+			storeErr: errors.Wrap(entities.ErrConflict, "Symbol is already tracked: ETH"),
+			wantErr:  entities.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, storage := newSymbolService(t)
+			if tt.wantErr != entities.ErrInvalidParams {
+				storage.EXPECT().AddSymbol(gomock.Any(), gomock.Any()).Return(tt.storeErr)
+			}
+
+			symbol, err := s.AddSymbol(context.Background(), tt.title)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, symbol.Title)
+		})
+	}
+}
+
+func TestService_SetSymbolPaused(t *testing.T) {
+	s, storage := newSymbolService(t)
+
+	storage.EXPECT().SetSymbolPaused(gomock.Any(), "BTC", true).Return(&entities.Symbol{Title: "BTC", Paused: true}, nil)
+	symbol, err := s.SetSymbolPaused(context.Background(), "btc", true)
+	require.NoError(t, err)
+	assert.True(t, symbol.Paused)
+
+	storage.EXPECT().SetSymbolPaused(gomock.Any(), "XRP", false).Return(nil, errors.Wrap(entities.ErrNotFound, "Unable to update symbol: XRP"))
+	_, err = s.SetSymbolPaused(context.Background(), "XRP", false)
+	assert.ErrorIs(t, err, entities.ErrNotFound)
+}
+
+func TestService_DeleteSymbol(t *testing.T) {
+	s, storage := newSymbolService(t)
+
+	storage.EXPECT().DeleteSymbol(gomock.Any(), "BTC").Return(nil)
+	require.NoError(t, s.DeleteSymbol(context.Background(), "btc"))
+
+	assert.ErrorIs(t, s.DeleteSymbol(context.Background(), "b-t-c"), entities.ErrInvalidParams)
+}
+
+func TestService_SeedSymbols(t *testing.T) {
+	t.Run("seeds empty table", func(t *testing.T) {
+		s, storage := newSymbolService(t)
+		gomock.InOrder(
+			storage.EXPECT().GetSymbols(gomock.Any()).Return(nil, nil),
+			storage.EXPECT().AddSymbol(gomock.Any(), &entities.Symbol{Title: "BTC"}).Return(nil),
+			storage.EXPECT().AddSymbol(gomock.Any(), &entities.Symbol{Title: "ETH"}).
+				Return(errors.Wrap(entities.ErrConflict, "Symbol is already tracked: ETH")),
+		)
+
+		require.NoError(t, s.SeedSymbols(context.Background(), []string{"BTC", "ETH"}))
+	})
+
+	t.Run("keeps tracked symbols", func(t *testing.T) {
+		s, storage := newSymbolService(t)
+		storage.EXPECT().GetSymbols(gomock.Any()).Return([]entities.Symbol{{Title: "ETH"}}, nil)
+
+		require.NoError(t, s.SeedSymbols(context.Background(), []string{"BTC", "ETH"}))
+	})
+
+	t.Run("invalid title", func(t *testing.T) {
+		s, storage := newSymbolService(t)
+		storage.EXPECT().GetSymbols(gomock.Any()).Return(nil, nil)
+
+		assert.ErrorIs(t, s.SeedSymbols(context.Background(), []string{"BTC/USD"}), entities.ErrInvalidParams)
+	})
+}
@@ -0,0 +1,447 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: currency.proto
+
+package currencyv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPriceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fsyms is required.
+	Fsyms []string `protobuf:"bytes,1,rep,name=fsyms,proto3" json:"fsyms,omitempty"`
+	// tsyms defaults to every stored quote.
+	Tsyms []string `protobuf:"bytes,2,rep,name=tsyms,proto3" json:"tsyms,omitempty"`
+	// from, to and window bound aggregates, all history by default. window is
+	// relative to now and excludes from and to. GetLastPrice ignores them.
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Window        *durationpb.Duration   `protobuf:"bytes,5,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceRequest) Reset() {
+	*x = GetPriceRequest{}
+	mi := &file_currency_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceRequest) ProtoMessage() {}
+
+func (x *GetPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPriceRequest) GetFsyms() []string {
+	if x != nil {
+		return x.Fsyms
+	}
+	return nil
+}
+
+func (x *GetPriceRequest) GetTsyms() []string {
+	if x != nil {
+		return x.Tsyms
+	}
+	return nil
+}
+
+func (x *GetPriceRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetPriceRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPriceRequest) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type GetPriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coins         []*Coin                `protobuf:"bytes,1,rep,name=coins,proto3" json:"coins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceResponse) Reset() {
+	*x = GetPriceResponse{}
+	mi := &file_currency_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceResponse) ProtoMessage() {}
+
+func (x *GetPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceResponse) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPriceResponse) GetCoins() []*Coin {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+type StreamPricesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fsyms defaults to every title.
+	Fsyms []string `protobuf:"bytes,1,rep,name=fsyms,proto3" json:"fsyms,omitempty"`
+	// tsyms defaults to every quote.
+	Tsyms         []string `protobuf:"bytes,2,rep,name=tsyms,proto3" json:"tsyms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPricesRequest) Reset() {
+	*x = StreamPricesRequest{}
+	mi := &file_currency_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPricesRequest) ProtoMessage() {}
+
+func (x *StreamPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPricesRequest.ProtoReflect.Descriptor instead.
+func (*StreamPricesRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamPricesRequest) GetFsyms() []string {
+	if x != nil {
+		return x.Fsyms
+	}
+	return nil
+}
+
+func (x *StreamPricesRequest) GetTsyms() []string {
+	if x != nil {
+		return x.Tsyms
+	}
+	return nil
+}
+
+type StreamPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coins         []*Coin                `protobuf:"bytes,1,rep,name=coins,proto3" json:"coins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPricesResponse) Reset() {
+	*x = StreamPricesResponse{}
+	mi := &file_currency_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPricesResponse) ProtoMessage() {}
+
+func (x *StreamPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPricesResponse.ProtoReflect.Descriptor instead.
+func (*StreamPricesResponse) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamPricesResponse) GetCoins() []*Coin {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+type Coin struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Title string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Quote string                 `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	// price is rounded to a double; price_decimal is exact.
+	Price      float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// from is set only on aggregates, which cover prices created between from
+	// and create_time.
+	From     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	Provider string                 `protobuf:"bytes,6,opt,name=provider,proto3" json:"provider,omitempty"`
+	// price_decimal is the exact price as a decimal string, e.g. "8398290.1".
+	PriceDecimal  string `protobuf:"bytes,7,opt,name=price_decimal,json=priceDecimal,proto3" json:"price_decimal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coin) Reset() {
+	*x = Coin{}
+	mi := &file_currency_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coin) ProtoMessage() {}
+
+func (x *Coin) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coin.ProtoReflect.Descriptor instead.
+func (*Coin) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Coin) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Coin) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *Coin) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Coin) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Coin) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *Coin) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Coin) GetPriceDecimal() string {
+	if x != nil {
+		return x.PriceDecimal
+	}
+	return ""
+}
+
+var File_currency_proto protoreflect.FileDescriptor
+
+const file_currency_proto_rawDesc = "" +
+	"\n" +
+	"\x0ecurrency.proto\x12\vcurrency.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcc\x01\n" +
+	"\x0fGetPriceRequest\x12\x14\n" +
+	"\x05fsyms\x18\x01 \x03(\tR\x05fsyms\x12\x14\n" +
+	"\x05tsyms\x18\x02 \x03(\tR\x05tsyms\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x121\n" +
+	"\x06window\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06window\";\n" +
+	"\x10GetPriceResponse\x12'\n" +
+	"\x05coins\x18\x01 \x03(\v2\x11.currency.v1.CoinR\x05coins\"A\n" +
+	"\x13StreamPricesRequest\x12\x14\n" +
+	"\x05fsyms\x18\x01 \x03(\tR\x05fsyms\x12\x14\n" +
+	"\x05tsyms\x18\x02 \x03(\tR\x05tsyms\"?\n" +
+	"\x14StreamPricesResponse\x12'\n" +
+	"\x05coins\x18\x01 \x03(\v2\x11.currency.v1.CoinR\x05coins\"\xf6\x01\n" +
+	"\x04Coin\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x14\n" +
+	"\x05quote\x18\x02 \x01(\tR\x05quote\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12.\n" +
+	"\x04from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12\x1a\n" +
+	"\bprovider\x18\x06 \x01(\tR\bprovider\x12#\n" +
+	"\rprice_decimal\x18\a \x01(\tR\fpriceDecimal2\x99\x03\n" +
+	"\x0fCurrencyService\x12K\n" +
+	"\fGetLastPrice\x12\x1c.currency.v1.GetPriceRequest\x1a\x1d.currency.v1.GetPriceResponse\x12J\n" +
+	"\vGetMaxPrice\x12\x1c.currency.v1.GetPriceRequest\x1a\x1d.currency.v1.GetPriceResponse\x12J\n" +
+	"\vGetMinPrice\x12\x1c.currency.v1.GetPriceRequest\x1a\x1d.currency.v1.GetPriceResponse\x12J\n" +
+	"\vGetAvgPrice\x12\x1c.currency.v1.GetPriceRequest\x1a\x1d.currency.v1.GetPriceResponse\x12U\n" +
+	"\fStreamPrices\x12 .currency.v1.StreamPricesRequest\x1a!.currency.v1.StreamPricesResponse0\x01B)Z'currency/pkg/api/currency/v1;currencyv1b\x06proto3"
+
+var (
+	file_currency_proto_rawDescOnce sync.Once
+	file_currency_proto_rawDescData []byte
+)
+
+func file_currency_proto_rawDescGZIP() []byte {
+	file_currency_proto_rawDescOnce.Do(func() {
+		file_currency_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_currency_proto_rawDesc), len(file_currency_proto_rawDesc)))
+	})
+	return file_currency_proto_rawDescData
+}
+
+var file_currency_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_currency_proto_goTypes = []any{
+	(*GetPriceRequest)(nil),       // 0: currency.v1.GetPriceRequest
+	(*GetPriceResponse)(nil),      // 1: currency.v1.GetPriceResponse
+	(*StreamPricesRequest)(nil),   // 2: currency.v1.StreamPricesRequest
+	(*StreamPricesResponse)(nil),  // 3: currency.v1.StreamPricesResponse
+	(*Coin)(nil),                  // 4: currency.v1.Coin
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 6: google.protobuf.Duration
+}
+var file_currency_proto_depIdxs = []int32{
+	5,  // 0: currency.v1.GetPriceRequest.from:type_name -> google.protobuf.Timestamp
+	5,  // 1: currency.v1.GetPriceRequest.to:type_name -> google.protobuf.Timestamp
+	6,  // 2: currency.v1.GetPriceRequest.window:type_name -> google.protobuf.Duration
+	4,  // 3: currency.v1.GetPriceResponse.coins:type_name -> currency.v1.Coin
+	4,  // 4: currency.v1.StreamPricesResponse.coins:type_name -> currency.v1.Coin
+	5,  // 5: currency.v1.Coin.create_time:type_name -> google.protobuf.Timestamp
+	5,  // 6: currency.v1.Coin.from:type_name -> google.protobuf.Timestamp
+	0,  // 7: currency.v1.CurrencyService.GetLastPrice:input_type -> currency.v1.GetPriceRequest
+	0,  // 8: currency.v1.CurrencyService.GetMaxPrice:input_type -> currency.v1.GetPriceRequest
+	0,  // 9: currency.v1.CurrencyService.GetMinPrice:input_type -> currency.v1.GetPriceRequest
+	0,  // 10: currency.v1.CurrencyService.GetAvgPrice:input_type -> currency.v1.GetPriceRequest
+	2,  // 11: currency.v1.CurrencyService.StreamPrices:input_type -> currency.v1.StreamPricesRequest
+	1,  // 12: currency.v1.CurrencyService.GetLastPrice:output_type -> currency.v1.GetPriceResponse
+	1,  // 13: currency.v1.CurrencyService.GetMaxPrice:output_type -> currency.v1.GetPriceResponse
+	1,  // 14: currency.v1.CurrencyService.GetMinPrice:output_type -> currency.v1.GetPriceResponse
+	1,  // 15: currency.v1.CurrencyService.GetAvgPrice:output_type -> currency.v1.GetPriceResponse
+	3,  // 16: currency.v1.CurrencyService.StreamPrices:output_type -> currency.v1.StreamPricesResponse
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_currency_proto_init() }
+func file_currency_proto_init() {
+	if File_currency_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_currency_proto_rawDesc), len(file_currency_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_currency_proto_goTypes,
+		DependencyIndexes: file_currency_proto_depIdxs,
+		MessageInfos:      file_currency_proto_msgTypes,
+	}.Build()
+	File_currency_proto = out.File
+	file_currency_proto_goTypes = nil
+	file_currency_proto_depIdxs = nil
+}
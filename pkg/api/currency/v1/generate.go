@@ -0,0 +1,4 @@
+// Package currencyv1 is the gRPC API generated from currency.proto.
+package currencyv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative currency.proto
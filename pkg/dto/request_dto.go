@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+// AlertRequestDTO creates or replaces an alert. Condition is above, below or
+// percent_change; for percent_change Threshold is in percent. Cooldown is a
+// duration such as 30m or 1h. Threshold is a decimal string or a JSON number.
+type AlertRequestDTO struct {
+	Title      string          `json:"title"`
+	Quote      string          `json:"quote"`
+	Condition  string          `json:"condition"`
+	Threshold  decimal.Decimal `json:"threshold" swaggertype:"string"`
+	Cooldown   string          `json:"cooldown,omitempty"`
+	WebhookURL string          `json:"webhook_url"`
+	Secret     string          `json:"secret,omitempty"`
+}
+
+// SymbolRequestDTO adds a tracked symbol.
+type SymbolRequestDTO struct {
+	Title string `json:"title"`
+}
+
+// SymbolPauseDTO pauses or resumes a tracked symbol.
+type SymbolPauseDTO struct {
+	Paused bool `json:"paused"`
+}
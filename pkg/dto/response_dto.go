@@ -1,9 +1,121 @@
 package dto
 
+import "github.com/shopspring/decimal"
+
+// Prices are encoded as exact decimal strings, e.g. "8398290.1".
 type CoinDTO struct {
-	Title      string  `json:"title"`
-	Price      float64 `json:"price"`
-	CreateTime string  `json:"create_time"`
+	Title      string          `json:"title"`
+	Quote      string          `json:"quote"`
+	Price      decimal.Decimal `json:"price" swaggertype:"string"`
+	CreateTime string          `json:"create_time"`
+	From       string          `json:"from,omitempty"`
+	To         string          `json:"to,omitempty"`
+	Provider   string          `json:"provider,omitempty"`
 }
 
 type CoinsDTO []CoinDTO
+
+type CandleDTO struct {
+	Title string          `json:"title"`
+	Quote string          `json:"quote"`
+	Start string          `json:"start"`
+	End   string          `json:"end"`
+	Open  decimal.Decimal `json:"open" swaggertype:"string"`
+	High  decimal.Decimal `json:"high" swaggertype:"string"`
+	Low   decimal.Decimal `json:"low" swaggertype:"string"`
+	Close decimal.Decimal `json:"close" swaggertype:"string"`
+}
+
+type CandlesDTO []CandleDTO
+
+// StreamMessageDTO is exchanged over /v1/stream. Clients send "subscribe" and
+// "unsubscribe" with Fsyms (and Tsyms), the server answers "subscribed" with
+// the current Fsyms and pushes "price" with Coins.
+type StreamMessageDTO struct {
+	Type  string   `json:"type"`
+	Fsyms []string `json:"fsyms,omitempty"`
+	Tsyms []string `json:"tsyms,omitempty"`
+	Coins CoinsDTO `json:"coins,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+type AlertDTO struct {
+	ID          int64            `json:"id"`
+	Title       string           `json:"title"`
+	Quote       string           `json:"quote"`
+	Condition   string           `json:"condition"`
+	Threshold   decimal.Decimal  `json:"threshold" swaggertype:"string"`
+	Cooldown    string           `json:"cooldown"`
+	WebhookURL  string           `json:"webhook_url"`
+	Secret      string           `json:"secret,omitempty"`
+	LastPrice   *decimal.Decimal `json:"last_price,omitempty" swaggertype:"string"`
+	LastFiredAt string           `json:"last_fired_at,omitempty"`
+	CreateTime  string           `json:"create_time"`
+}
+
+type AlertsDTO []AlertDTO
+
+type DeliveryDTO struct {
+	ID         int64  `json:"id"`
+	Attempt    int    `json:"attempt"`
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+	CreateTime string `json:"create_time"`
+}
+
+type DeliveriesDTO []DeliveryDTO
+
+// AlertEventDTO is the body of an alert webhook. Retries of one event share
+// AlertID and FiredAt.
+type AlertEventDTO struct {
+	AlertID   int64           `json:"alert_id"`
+	Title     string          `json:"title"`
+	Quote     string          `json:"quote"`
+	Condition string          `json:"condition"`
+	Threshold decimal.Decimal `json:"threshold" swaggertype:"string"`
+	Price     decimal.Decimal `json:"price" swaggertype:"string"`
+	PriceTime string          `json:"price_time"`
+	FiredAt   string          `json:"fired_at"`
+}
+
+// ReadinessDTO reports every readiness check as "ok" or the reason it failed.
+type ReadinessDTO struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+type SymbolStatusDTO struct {
+	Title      string `json:"title"`
+	Quote      string `json:"quote"`
+	UpdateTime string `json:"update_time"`
+	Stale      bool   `json:"stale"`
+}
+
+type StatusDTO struct {
+	LastFetch      string            `json:"last_fetch,omitempty"`
+	Symbols        []SymbolStatusDTO `json:"symbols"`
+	Instance       string            `json:"instance,omitempty"`
+	Leader         bool              `json:"leader"`
+	LeaderInstance string            `json:"leader_instance,omitempty"`
+}
+
+type SymbolDTO struct {
+	Title      string `json:"title"`
+	Paused     bool   `json:"paused"`
+	CreateTime string `json:"create_time"`
+}
+
+type SymbolsDTO []SymbolDTO
+
+// SchedulerJobDTO is one schedule; Symbols is empty for the default one,
+// which updates every symbol without an override.
+type SchedulerJobDTO struct {
+	Symbols  []string `json:"symbols,omitempty"`
+	Schedule string   `json:"schedule"`
+	NextRun  string   `json:"next_run,omitempty"`
+}
+
+type SchedulerDTO struct {
+	Paused bool              `json:"paused"`
+	Jobs   []SchedulerJobDTO `json:"jobs"`
+}